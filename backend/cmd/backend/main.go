@@ -1,10 +1,16 @@
 package main
 
 import (
+	alertshandler "app-pinger/backend/internal/api/handlers/alerts"
 	containershandler "app-pinger/backend/internal/api/handlers/containers"
+	eventshandler "app-pinger/backend/internal/api/handlers/events"
+	incidentshandler "app-pinger/backend/internal/api/handlers/incidents"
+	silenceshandler "app-pinger/backend/internal/api/handlers/silences"
 	"app-pinger/backend/internal/api/handlers/verifier"
+	webhookshandler "app-pinger/backend/internal/api/handlers/webhooks"
 	"app-pinger/backend/internal/api/utilapi"
 	"app-pinger/backend/internal/config"
+	"app-pinger/backend/internal/notifier"
 	"app-pinger/backend/internal/usecase"
 	repo "app-pinger/backend/internal/usecase/repo/postgres"
 	"app-pinger/pkg/loger"
@@ -30,6 +36,10 @@ func main() {
 
 	virifierCfg := config.LoadVerifierConfiger()
 
+	notifierCfg := config.LoadNotifierConfig()
+
+	rulesCfg := config.LoadRulesConfig()
+
 	log := loger.SetupLogger(cfg.LogLevel)
 
 	log.Info("starting backend-server")
@@ -67,21 +77,81 @@ func main() {
 	}
 	defer rabbitMQ.Close()
 
+	eventsMQ, err := queue.NewConnection(cfg.RabbitMQPath, cfg.RabbitMQ.EventsQueue)
+	if err != nil {
+		log.Error("failed to create rabbitMQ events connection", slog.Any("error", err))
+	}
+	defer eventsMQ.Close()
+
 	containers := repo.NewContainerRepo(db)
+	incidents := repo.NewIncidentRepo(db)
+	deliveries := repo.NewDeliveryRepo(db)
+	alerts := repo.NewAlertRepo(db)
+	silences := repo.NewSilenceRepo(db)
+	events := repo.NewEventRepo(db)
 
-	containerUseCase := usecase.NewBackendService(containers)
+	webhookNotifier := notifier.NewWebhookNotifier(notifierCfg, deliveries, log)
 
-	containerHandler := containershandler.NewContainersHandler(containerUseCase, rabbitMQ)
+	containerUseCase := usecase.NewBackendService(containers)
+	alertEvaluator := usecase.NewAlertEvaluator(rulesCfg.AlertRules(), containerUseCase, alerts, webhookNotifier)
+	flappingDetector := usecase.NewFlappingDetector(cfg.FlapWindow, cfg.FlapCount)
+	monitor := usecase.NewMonitor(containerUseCase, incidents, silences, webhookNotifier,
+		alertEvaluator, flappingDetector)
+
+	containerHandler := containershandler.NewContainersHandler(containerUseCase, monitor, rabbitMQ)
+	incidentsHandler := incidentshandler.NewIncidentsHandler(incidents)
+	eventsHandler := eventshandler.NewEventsHandler(events, incidents, monitor, eventsMQ)
+	webhooksHandler := webhookshandler.NewWebhooksHandler(deliveries)
+	alertsHandler := alertshandler.NewAlertsHandler(alerts)
+	silencesHandler := silenceshandler.NewSilencesHandler(silences)
 	verifierHandler := verifier.NewVerifier(virifierCfg.Keys, virifierCfg.RateLimit, virifierCfg.RateTime)
 
 	router := utilapi.NewRouter(log)
 
+	// очереди webhook'ов разбираются до остановки сервиса
+	notifierCtx, stopNotifier := context.WithCancel(context.Background())
+	notifierDone := make(chan struct{})
+	go func() {
+		webhookNotifier.Run(notifierCtx)
+		close(notifierDone)
+	}()
+
 	// RabbitMQ обработчик
 	go func() {
 		containerHandler.ProcessQueue(log)
 	}()
 
+	go func() {
+		eventsHandler.ProcessQueue(log)
+	}()
+
+	// контейнеры, удаленные pinger'ом, хранятся GONE_RETENTION, затем удаляются вместе с историей
+	go func() {
+		ticker := time.NewTicker(cfg.GonePurge)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			deleted, err := containerUseCase.DeleteGone(context.Background(), time.Now().Add(-cfg.GoneTTL))
+			if err != nil {
+				log.Error("failed to delete gone containers", slog.Any("error", err))
+				continue
+			}
+			if deleted > 0 {
+				log.Info("gone containers deleted", slog.Int64("count", deleted))
+			}
+		}
+	}()
+
 	router.Handle("/container/getall", verifierHandler.Verify, containerHandler.GetAll)
+	router.Handle("GET /container/{key}/history", verifierHandler.Verify, containerHandler.History)
+	router.Handle("GET /container/{key}/uptime", verifierHandler.Verify, containerHandler.Uptime)
+	router.Handle("GET /container/{key}/timeline", verifierHandler.Verify, eventsHandler.Timeline)
+	router.Handle("GET /incidents", verifierHandler.Verify, incidentsHandler.GetAll)
+	router.Handle("GET /alerts", verifierHandler.Verify, alertsHandler.GetAll)
+	router.Handle("POST /silences", verifierHandler.Verify, silencesHandler.Add)
+	router.Handle("GET /silences", verifierHandler.Verify, silencesHandler.GetAll)
+	router.Handle("DELETE /silences/{id}", verifierHandler.Verify, silencesHandler.Delete)
+	router.Handle("GET /webhooks/deliveries", verifierHandler.Verify, webhooksHandler.GetDeliveries)
 
 	srv := &http.Server{
 		Addr:         cfg.Addr,
@@ -97,7 +167,8 @@ func main() {
 
 	log.Info("backend-server started")
 	log.Debug("server settings", slog.Any("Address", cfg.Addr), slog.Any("ReadTimeout", cfg.Timeout),
-		slog.Any("WriteTimeout", cfg.Timeout), slog.Any("IdleTimeout", cfg.IdleTimeout))
+		slog.Any("WriteTimeout", cfg.Timeout), slog.Any("IdleTimeout", cfg.IdleTimeout),
+		slog.Any("GoneRetention", cfg.GoneTTL))
 
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
@@ -108,6 +179,13 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	stopNotifier()
+	select {
+	case <-notifierDone:
+	case <-ctx.Done():
+		log.Error("failed to stop notifier", slog.Any("error", ctx.Err()))
+	}
+
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Error("failed to stop server", slog.Any("error", err))
 
@@ -0,0 +1,15 @@
+package alertshandler
+
+import (
+	"app-pinger/backend/internal/usecase"
+)
+
+type AlertsHandler struct {
+	alerts usecase.AlertRepo
+}
+
+func NewAlertsHandler(a usecase.AlertRepo) *AlertsHandler {
+	return &AlertsHandler{
+		alerts: a,
+	}
+}
@@ -0,0 +1,76 @@
+package alertshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/entity"
+	storagemock "app-pinger/backend/internal/usecase/repo/mock"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAlertsHandler_GetAll(t *testing.T) {
+	startedAt := time.Now().UTC().Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		url   string
+		want  interface{}
+		count int
+	}{
+		{
+			name:  "Valid (all alerts)",
+			url:   "/alerts",
+			want:  http.StatusOK,
+			count: 3,
+		},
+		{
+			name:  "Valid (firing alerts)",
+			url:   "/alerts?state=firing",
+			want:  http.StatusOK,
+			count: 2,
+		},
+		{
+			name:  "Valid (alerts of rule and container)",
+			url:   "/alerts?rule=down&ip=192.168.0.1",
+			want:  http.StatusOK,
+			count: 1,
+		},
+		{
+			name: "Invalid state",
+			url:  "/alerts?state=pending",
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := storagemock.NewMockAlertRepo(
+				entity.Alert{ID: 1, Rule: "down", IP: "192.168.0.1", StartedAt: startedAt, ResolvedAt: startedAt.Add(time.Minute)},
+				entity.Alert{ID: 2, Rule: "slow", IP: "192.168.0.1", Value: 120, StartedAt: startedAt},
+				entity.Alert{ID: 3, Rule: "down", IP: "192.168.0.2", Value: 3, StartedAt: startedAt},
+			)
+			h := NewAlertsHandler(mockRepo)
+
+			r := utilapi.NewRouter(slog.Default())
+			r.Handle("GET /alerts", h.GetAll)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.want, w.Code)
+
+			if w.Code == http.StatusOK {
+				var resp []AlertResp
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Len(t, resp, tt.count)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package alertshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"errors"
+	"net/http"
+	"time"
+)
+
+type AlertResp struct {
+	ID         int64   `json:"id"`
+	Rule       string  `json:"rule"`
+	Key        string  `json:"key"`
+	IPAddress  string  `json:"ip_address"`
+	State      string  `json:"state"`
+	Value      float64 `json:"value"`
+	StartedAt  string  `json:"started_at"`
+	ResolvedAt string  `json:"resolved_at,omitempty"`
+}
+
+func (a *AlertsHandler) GetAll(ctx *utilapi.APIContext) {
+	filter := usecase.AlertFilter{
+		Key:   ctx.GetFromQuery("key"),
+		IP:    ctx.GetFromQuery("ip"),
+		Rule:  ctx.GetFromQuery("rule"),
+		State: ctx.GetFromQuery("state"),
+	}
+
+	if filter.State != "" && filter.State != entity.AlertFiring && filter.State != entity.AlertResolved {
+		ctx.Error("failed to parse filter", errors.New("unknown alert state"))
+		ctx.WriteFailure(http.StatusBadRequest, "invalid state")
+		return
+	}
+
+	alerts, err := a.alerts.GetAll(ctx, filter)
+	if err != nil {
+		ctx.Error("failed to get alerts", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	data := make([]AlertResp, len(alerts))
+
+	for i, alert := range alerts {
+		data[i] = AlertResp{
+			ID:        alert.ID,
+			Rule:      alert.Rule,
+			Key:       alert.Key,
+			IPAddress: alert.IP,
+			State:     alert.State(),
+			Value:     alert.Value,
+			StartedAt: alert.StartedAt.Format(time.DateTime),
+		}
+
+		if alert.State() == entity.AlertResolved {
+			data[i].ResolvedAt = alert.ResolvedAt.Format(time.DateTime)
+		}
+	}
+
+	ctx.SuccessWithData(data)
+}
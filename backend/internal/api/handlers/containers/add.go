@@ -39,20 +39,86 @@ func (c *ContainersHandler) ProcessQueue(log *slog.Logger) {
 			lastPing, err := time.Parse(time.DateTime, r.LastPing)
 			if err != nil {
 				log.Error("failed encode containers", slog.Any("error", err))
-				return
+				continue
 			}
 
 			container := entity.Container{
 				IP:          r.IPAddress,
+				Family:      r.Family,
 				IsReachable: r.IsReachable,
 				LastPing:    lastPing,
+				CheckError:  r.CheckError,
+				ContainerInfo: entity.ContainerInfo{
+					Key:         r.Key,
+					ContainerID: r.ContainerID,
+					Name:        r.Name,
+					Image:       r.Image,
+					Project:     r.Project,
+					Service:     r.Service,
+					Network:     r.Network,
+					Labels:      r.Labels,
+				},
+				PingStats: entity.PingStats{
+					PacketsSent: r.PacketsSent,
+					PacketsRecv: r.PacketsRecv,
+					PacketLoss:  r.PacketLoss,
+					MinRtt:      r.MinRtt,
+					AvgRtt:      r.AvgRtt,
+					MaxRtt:      r.MaxRtt,
+					StdDevRtt:   r.StdDevRtt,
+					Jitter:      r.Jitter,
+				},
 			}
 
-			IP, err := c.containers.Add(context.Background(), container)
-			if err != nil || IP != r.IPAddress {
-				log.Error("failed to add container", slog.Any("error", err))
-				return
+			// pinger без поддержки IPv6 не передает семейство адреса, оно определяется по IP
+			if container.Family != contracts.FamilyIPv4 && container.Family != contracts.FamilyIPv6 {
+				container.Family = contracts.IPFamily(r.IPAddress)
+			}
+			if container.Family == "" {
+				log.Error("failed to add container", slog.String("key", r.Key),
+					slog.Any("error", "invalid IP address"))
+				continue
+			}
+
+			if r.Health != nil {
+				container.Health = entity.Health{
+					Status:        r.Health.Status,
+					FailingStreak: r.Health.FailingStreak,
+					Output:        r.Health.Output,
+				}
+			}
+
+			for _, probe := range r.Probes {
+				container.Probes = append(container.Probes, newProbe(probe))
+			}
+
+			// ошибка одного результата не должна останавливать прием остальных
+			err = c.monitor.Process(context.Background(), container)
+			if err != nil {
+				log.Error("failed to add container", slog.String("key", container.Key), slog.Any("error", err))
+				continue
 			}
 		}
 	}
 }
+
+func newProbe(probe contracts.ProbeResult) entity.Probe {
+	p := entity.Probe{
+		Type:       probe.Type,
+		Target:     probe.Target,
+		Port:       probe.Port,
+		Success:    probe.Success,
+		Latency:    probe.Latency,
+		StatusCode: probe.StatusCode,
+		Addresses:  probe.Addresses,
+		Status:     probe.Status,
+		Error:      probe.Error,
+	}
+
+	if probe.CertExpiry != "" {
+		// некорректная дата не должна отбрасывать результат пинга
+		p.CertExpiry, _ = time.Parse(time.DateTime, probe.CertExpiry)
+	}
+
+	return p
+}
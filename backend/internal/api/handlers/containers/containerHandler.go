@@ -7,12 +7,14 @@ import (
 
 type ContainersHandler struct {
 	containers usecase.ContainerRepo
+	monitor    *usecase.Monitor
 	rabbitMQ   queue.RabbitMQ
 }
 
-func NewContainersHandler(c usecase.ContainerRepo, r queue.RabbitMQ) *ContainersHandler {
+func NewContainersHandler(c usecase.ContainerRepo, m *usecase.Monitor, r queue.RabbitMQ) *ContainersHandler {
 	return &ContainersHandler{
 		containers: c,
+		monitor:    m,
 		rabbitMQ:   r,
 	}
 }
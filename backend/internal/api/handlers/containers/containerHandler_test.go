@@ -3,56 +3,420 @@ package containershandler
 import (
 	"app-pinger/backend/internal/api/utilapi"
 	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
 	storagemock "app-pinger/backend/internal/usecase/repo/mock"
 	"app-pinger/pkg/contracts"
 	mockqueue "app-pinger/pkg/queue/mock"
 	"bytes"
+	"context"
 	"encoding/json"
 	"github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/require"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 )
 
 func TestContainersHandler_GetAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		container entity.Container
+		want      interface{}
+		status    string
+	}{
+		{
+			name:      "Valid",
+			container: entity.Container{IP: "192.168.0.1", IsReachable: true, LastPing: time.Now()},
+			want:      http.StatusOK,
+			status:    entity.ContainerUp,
+		},
+		{
+			name: "Valid (pingable but unhealthy)",
+			container: entity.Container{
+				IP:          "192.168.0.1",
+				IsReachable: true,
+				LastPing:    time.Now(),
+				Health:      entity.Health{Status: entity.HealthUnhealthy, FailingStreak: 3, Output: "exit 1"},
+			},
+			want:   http.StatusOK,
+			status: entity.ContainerUnhealthy,
+		},
+		{
+			name: "Valid (unreachable)",
+			container: entity.Container{
+				IP:       "192.168.0.1",
+				LastPing: time.Now(),
+				Health:   entity.Health{Status: entity.HealthHealthy},
+			},
+			want:   http.StatusOK,
+			status: entity.ContainerDown,
+		},
+		{
+			name: "Valid (unreachable in maintenance)",
+			container: entity.Container{
+				IP:            "192.168.0.1",
+				LastPing:      time.Now(),
+				InMaintenance: true,
+			},
+			want:   http.StatusOK,
+			status: entity.ContainerMaintenance,
+		},
+		{
+			name: "Valid (ping could not run)",
+			container: entity.Container{
+				IP:         "192.168.0.1",
+				LastPing:   time.Now(),
+				CheckError: "socket: permission denied",
+			},
+			want:   http.StatusOK,
+			status: entity.ContainerUnknown,
+		},
+		{
+			name: "Valid (gone)",
+			container: entity.Container{
+				IP:          "192.168.0.1",
+				IsReachable: true,
+				LastPing:    time.Now().Add(-time.Minute),
+				GoneAt:      time.Now(),
+			},
+			want:   http.StatusOK,
+			status: entity.ContainerGone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := storagemock.NewMockRepo(tt.container)
+			mockRabbit := new(mockqueue.MockRabbitMQ)
+			monitor := usecase.NewMonitor(mockRepo, storagemock.NewMockIncidentRepo(), nil, nil, nil, nil)
+			h := NewContainersHandler(mockRepo, monitor, mockRabbit)
+
+			r := utilapi.NewRouter(slog.Default())
+			r.Handle("/", h.GetAll)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected: %v get: %v", tt.want, w.Code)
+			}
+
+			var resp []ContainersResp
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Len(t, resp, 1)
+			require.Equal(t, tt.status, resp[0].Status)
+		})
+	}
+}
+
+func TestContainersHandler_GetAllMaintenance(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		silences    []entity.Silence
+		maintenance bool
+		status      string
+	}{
+		{
+			name:        "Active silence",
+			silences:    []entity.Silence{{IP: "192.168.0.1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}},
+			maintenance: true,
+			status:      entity.ContainerMaintenance,
+		},
+		{
+			name:   "Silence deleted",
+			status: entity.ContainerDown,
+		},
+		{
+			name:     "Silence expired",
+			silences: []entity.Silence{{IP: "192.168.0.1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(-time.Minute)}},
+			status:   entity.ContainerDown,
+		},
+		{
+			name:     "Silence for another container",
+			silences: []entity.Silence{{IP: "192.168.0.2", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}},
+			status:   entity.ContainerDown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := storagemock.NewMockRepo(entity.Container{
+				IP:            "192.168.0.1",
+				LastPing:      now.Add(-2 * time.Minute),
+				InMaintenance: true,
+			})
+			mockRabbit := new(mockqueue.MockRabbitMQ)
+			silences := storagemock.NewMockSilenceRepo(tt.silences...)
+			monitor := usecase.NewMonitor(mockRepo, storagemock.NewMockIncidentRepo(), silences, nil, nil, nil)
+			h := NewContainersHandler(mockRepo, monitor, mockRabbit)
+
+			r := utilapi.NewRouter(slog.Default())
+			r.Handle("/", h.GetAll)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var resp []ContainersResp
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Len(t, resp, 1)
+			require.Equal(t, tt.maintenance, resp[0].InMaintenance)
+			require.Equal(t, tt.status, resp[0].Status)
+		})
+	}
+}
+
+func TestContainersHandler_GetAllFamily(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		want  interface{}
+		count int
+	}{
+		{
+			name:  "Valid (all families)",
+			url:   "/",
+			want:  http.StatusOK,
+			count: 1,
+		},
+		{
+			name:  "Valid (IPv6)",
+			url:   "/?family=ipv6",
+			want:  http.StatusOK,
+			count: 1,
+		},
+		{
+			name:  "Valid (IPv4, no containers)",
+			url:   "/?family=ipv4",
+			want:  http.StatusOK,
+			count: 0,
+		},
+		{
+			name: "Invalid family",
+			url:  "/?family=ipx",
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := storagemock.NewMockRepo(entity.Container{
+				IP:            "fd00:10::2",
+				Family:        contracts.FamilyIPv6,
+				ContainerInfo: entity.ContainerInfo{Key: "app.web.1@app_default:ipv6"},
+				IsReachable:   true,
+				LastPing:      time.Now(),
+			})
+			mockRabbit := new(mockqueue.MockRabbitMQ)
+			monitor := usecase.NewMonitor(mockRepo, storagemock.NewMockIncidentRepo(), nil, nil, nil, nil)
+			h := NewContainersHandler(mockRepo, monitor, mockRabbit)
+
+			r := utilapi.NewRouter(slog.Default())
+			r.Handle("/", h.GetAll)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.want, w.Code)
+
+			if w.Code == http.StatusOK {
+				var resp []ContainersResp
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Len(t, resp, tt.count)
+				for _, c := range resp {
+					require.Equal(t, contracts.FamilyIPv6, c.Family)
+				}
+			}
+		})
+	}
+}
+
+func TestContainersHandler_History(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		want  interface{}
+		count int
+	}{
+		{
+			name:  "Valid (default range)",
+			url:   "/container/app.web.1@app_default/history",
+			want:  http.StatusOK,
+			count: 1,
+		},
+		{
+			name: "Valid (custom range)",
+			url: "/container/app.web.1@app_default/history?from=" + url.QueryEscape(time.Now().Add(-time.Hour).Format(time.DateTime)) +
+				"&to=" + url.QueryEscape(time.Now().Add(time.Hour).Format(time.DateTime)),
+			want:  http.StatusOK,
+			count: 1,
+		},
+		{
+			name:  "Valid (unknown container)",
+			url:   "/container/10.0.0.1/history",
+			want:  http.StatusOK,
+			count: 0,
+		},
+		{
+			name: "Invalid range (not data)",
+			url:  "/container/app.web.1@app_default/history?from=yesterday",
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid range (from after to)",
+			url:  "/container/app.web.1@app_default/history?from=2025-02-08+10:00:00&to=2025-02-08+09:00:00",
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := storagemock.NewMockRepo(entity.Container{
+				IP:            "192.168.0.1",
+				ContainerInfo: entity.ContainerInfo{Key: "app.web.1@app_default"},
+				IsReachable:   true,
+				LastPing:      time.Now(),
+			})
+			mockRabbit := new(mockqueue.MockRabbitMQ)
+			monitor := usecase.NewMonitor(mockRepo, storagemock.NewMockIncidentRepo(), nil, nil, nil, nil)
+			h := NewContainersHandler(mockRepo, monitor, mockRabbit)
+
+			r := utilapi.NewRouter(slog.Default())
+			r.Handle("GET /container/{key}/history", h.History)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.want, w.Code)
+
+			if w.Code == http.StatusOK {
+				var resp []HistoryResp
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Len(t, resp, tt.count)
+
+				var fields []map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fields))
+				for _, f := range fields {
+					require.Contains(t, f, "checked_at")
+					require.NotContains(t, f, "status")
+					require.NotContains(t, f, "in_maintenance")
+				}
+			}
+		})
+	}
+}
+
+func TestContainersHandler_Uptime(t *testing.T) {
 	tests := []struct {
 		name string
+		url  string
 		want interface{}
 	}{
 		{
-			name: "Valid",
+			name: "Valid (default window)",
+			url:  "/container/app.web.1@app_default/uptime",
+			want: http.StatusOK,
+		},
+		{
+			name: "Valid (7 days window)",
+			url:  "/container/app.web.1@app_default/uptime?window=7d",
+			want: http.StatusOK,
+		},
+		{
+			name: "Valid (hours window)",
+			url:  "/container/app.web.1@app_default/uptime?window=12h",
 			want: http.StatusOK,
 		},
+		{
+			name: "Invalid window",
+			url:  "/container/app.web.1@app_default/uptime?window=week",
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid window (negative)",
+			url:  "/container/app.web.1@app_default/uptime?window=-1d",
+			want: http.StatusBadRequest,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := storagemock.NewMockRepo(entity.Container{
-				IP:          "192.168.0.1",
-				IsReachable: true,
-				LastPing:    time.Now(),
+				IP:            "192.168.0.1",
+				ContainerInfo: entity.ContainerInfo{Key: "app.web.1@app_default"},
+				IsReachable:   true,
+				LastPing:      time.Now().UTC().Add(-time.Minute),
 			})
 			mockRabbit := new(mockqueue.MockRabbitMQ)
-			h := NewContainersHandler(mockRepo, mockRabbit)
+			monitor := usecase.NewMonitor(mockRepo, storagemock.NewMockIncidentRepo(), nil, nil, nil, nil)
+			h := NewContainersHandler(mockRepo, monitor, mockRabbit)
 
 			r := utilapi.NewRouter(slog.Default())
-			r.Handle("/", h.GetAll)
+			r.Handle("GET /container/{key}/uptime", h.Uptime)
 
-			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
 
 			w := httptest.NewRecorder()
 
 			r.ServeHTTP(w, req)
 
-			if w.Code != tt.want {
-				t.Errorf("expected: %v get: %v", tt.want, w.Code)
+			require.Equal(t, tt.want, w.Code)
+
+			if w.Code == http.StatusOK {
+				var resp UptimeResp
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Equal(t, 1, resp.Checks)
+				require.Equal(t, 1, resp.Up)
+				require.NotNil(t, resp.Availability)
+				require.Equal(t, float64(100), *resp.Availability)
 			}
 		})
 	}
 }
 
+func TestContainersHandler_UptimeUnknown(t *testing.T) {
+	mockRepo := storagemock.NewMockRepo(entity.Container{
+		IP:            "192.168.0.1",
+		ContainerInfo: entity.ContainerInfo{Key: "app.web.1@app_default"},
+		LastPing:      time.Now().UTC().Add(-time.Minute),
+		CheckError:    "socket: permission denied",
+	})
+	mockRabbit := new(mockqueue.MockRabbitMQ)
+	monitor := usecase.NewMonitor(mockRepo, storagemock.NewMockIncidentRepo(), nil, nil, nil, nil)
+	h := NewContainersHandler(mockRepo, monitor, mockRabbit)
+
+	r := utilapi.NewRouter(slog.Default())
+	r.Handle("GET /container/{key}/uptime", h.Uptime)
+
+	req := httptest.NewRequest(http.MethodGet, "/container/app.web.1@app_default/uptime", nil)
+
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"availability":null`)
+
+	var resp UptimeResp
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 1, resp.Checks)
+	require.Equal(t, 1, resp.Unknown)
+	require.Nil(t, resp.Availability)
+}
+
 func TestContainersHandler_ProcessQueue(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -86,6 +450,15 @@ func TestContainersHandler_ProcessQueue(t *testing.T) {
 			},
 			want: "failed decode json",
 		},
+		{
+			name: "Invalid container (IP - no address family)",
+			container: contracts.PingData{
+				IPAddress:   "not-an-ip",
+				IsReachable: true,
+				LastPing:    time.Now().Format(time.DateTime),
+			},
+			want: "invalid IP address",
+		},
 		{
 			name: "Invalid container (Last ping - zero data)",
 			container: contracts.PingData{
@@ -104,7 +477,8 @@ func TestContainersHandler_ProcessQueue(t *testing.T) {
 				LastPing:    time.Now(),
 			})
 			mockRabbit := new(mockqueue.MockRabbitMQ)
-			h := NewContainersHandler(mockRepo, mockRabbit)
+			monitor := usecase.NewMonitor(mockRepo, storagemock.NewMockIncidentRepo(), nil, nil, nil, nil)
+			h := NewContainersHandler(mockRepo, monitor, mockRabbit)
 
 			testReq := contracts.ContainerAddReq{
 				Containers: []contracts.PingData{
@@ -132,3 +506,34 @@ func TestContainersHandler_ProcessQueue(t *testing.T) {
 		})
 	}
 }
+
+func TestContainersHandler_ProcessQueueContinues(t *testing.T) {
+	mockRepo := storagemock.NewMockRepo(entity.Container{})
+	incidents := storagemock.NewMockIncidentRepo()
+	mockRabbit := new(mockqueue.MockRabbitMQ)
+	monitor := usecase.NewMonitor(mockRepo, incidents, nil, nil, nil, nil)
+	h := NewContainersHandler(mockRepo, monitor, mockRabbit)
+
+	broken, _ := json.Marshal(contracts.ContainerAddReq{Containers: []contracts.PingData{
+		{IPAddress: "192.168.1.1", LastPing: "1000-10-10", ContainerInfo: contracts.ContainerInfo{Key: "broken"}},
+	}})
+	valid, _ := json.Marshal(contracts.ContainerAddReq{Containers: []contracts.PingData{
+		{IPAddress: "192.168.1.2", LastPing: time.Now().Format(time.DateTime), ContainerInfo: contracts.ContainerInfo{Key: "app.web.1@app_default"}},
+	}})
+
+	msgChan := make(chan amqp091.Delivery, 2)
+	msgChan <- amqp091.Delivery{Body: broken}
+	msgChan <- amqp091.Delivery{Body: valid}
+	close(msgChan)
+
+	mockRabbit.On("Consume").Return(msgChan, nil)
+
+	var logBuffer bytes.Buffer
+	h.ProcessQueue(slog.New(slog.NewTextHandler(&logBuffer, nil)))
+
+	// некорректное сообщение не останавливает прием: по следующему открыт инцидент недоступности
+	require.Contains(t, logBuffer.String(), "failed encode containers")
+	incident, err := incidents.GetOpen(context.Background(), "app.web.1@app_default")
+	require.NoError(t, err)
+	require.NotNil(t, incident)
+}
@@ -2,34 +2,143 @@ package containershandler
 
 import (
 	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"app-pinger/pkg/contracts"
+	"errors"
 	"net/http"
 	"time"
 )
 
 type ContainersResp struct {
-	IPAddress   string `json:"ip_address"`
-	IsReachable bool   `json:"is_reachable"`
-	LastPing    string `json:"last_ping"`
+	Key           string            `json:"key"`
+	IPAddress     string            `json:"ip_address"`
+	Family        string            `json:"ip_family"`
+	ContainerID   string            `json:"container_id,omitempty"`
+	Name          string            `json:"container_name,omitempty"`
+	Image         string            `json:"image,omitempty"`
+	Project       string            `json:"compose_project,omitempty"`
+	Service       string            `json:"compose_service,omitempty"`
+	Network       string            `json:"network,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	IsReachable   bool              `json:"is_reachable"`
+	Status        string            `json:"status"`
+	HealthStatus  string            `json:"health_status,omitempty"`
+	FailingStreak int               `json:"failing_streak,omitempty"`
+	HealthOutput  string            `json:"health_output,omitempty"`
+	LastPing      string            `json:"last_ping"`
+	Flapping      bool              `json:"flapping"`
+	InMaintenance bool              `json:"in_maintenance"`
+	PacketsSent   int               `json:"packets_sent"`
+	PacketsRecv   int               `json:"packets_recv"`
+	PacketLoss    float64           `json:"packet_loss"`
+	MinRtt        float64           `json:"min_rtt"`
+	AvgRtt        float64           `json:"avg_rtt"`
+	MaxRtt        float64           `json:"max_rtt"`
+	StdDevRtt     float64           `json:"stddev_rtt"`
+	Jitter        float64           `json:"jitter"`
+	Probes        []ProbeResp       `json:"probes,omitempty"`
+	GoneAt        string            `json:"gone_at,omitempty"`
+	CheckError    string            `json:"check_error,omitempty"`
+}
+
+type ProbeResp struct {
+	Type       string   `json:"type"`
+	Target     string   `json:"target,omitempty"`
+	Port       int      `json:"port,omitempty"`
+	Success    bool     `json:"success"`
+	Latency    float64  `json:"latency"`
+	StatusCode int      `json:"status_code,omitempty"`
+	CertExpiry string   `json:"cert_expiry,omitempty"`
+	Addresses  []string `json:"addresses,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Error      string   `json:"error,omitempty"`
 }
 
 func (c *ContainersHandler) GetAll(ctx *utilapi.APIContext) {
-	containers, err := c.containers.GetAll(ctx)
+	filter := usecase.ContainerFilter{Family: ctx.GetFromQuery("family")}
+
+	if filter.Family != "" && filter.Family != contracts.FamilyIPv4 && filter.Family != contracts.FamilyIPv6 {
+		ctx.Error("failed to parse filter", errors.New("unknown address family"))
+		ctx.WriteFailure(http.StatusBadRequest, "invalid family")
+		return
+	}
+
+	containers, err := c.containers.GetAll(ctx, filter)
 	if err != nil {
 		ctx.Error("failed to get all containers", err)
 		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
 		return
 	}
 
+	if err = c.monitor.Maintenance(ctx, containers, time.Now()); err != nil {
+		ctx.Error("failed to check maintenance", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
 	data := make([]ContainersResp, len(containers))
 
 	for i, container := range containers {
+		data[i] = newContainersResp(container)
+	}
 
-		data[i] = ContainersResp{
-			IPAddress:   container.IP,
-			IsReachable: container.IsReachable,
-			LastPing:    container.LastPing.Format(time.DateTime),
+	ctx.SuccessWithData(data)
+}
+
+func newContainersResp(container entity.Container) ContainersResp {
+	resp := ContainersResp{
+		Key:           container.Key,
+		IPAddress:     container.IP,
+		Family:        container.Family,
+		ContainerID:   container.ContainerID,
+		Name:          container.Name,
+		Image:         container.Image,
+		Project:       container.Project,
+		Service:       container.Service,
+		Network:       container.Network,
+		Labels:        container.Labels,
+		IsReachable:   container.IsReachable,
+		Status:        container.Status(),
+		HealthStatus:  container.Health.Status,
+		FailingStreak: container.Health.FailingStreak,
+		HealthOutput:  container.Health.Output,
+		LastPing:      container.LastPing.Format(time.DateTime),
+		Flapping:      container.Flapping,
+		InMaintenance: container.InMaintenance,
+		PacketsSent:   container.PacketsSent,
+		PacketsRecv:   container.PacketsRecv,
+		PacketLoss:    container.PacketLoss,
+		MinRtt:        container.MinRtt,
+		AvgRtt:        container.AvgRtt,
+		MaxRtt:        container.MaxRtt,
+		StdDevRtt:     container.StdDevRtt,
+		Jitter:        container.Jitter,
+		CheckError:    container.CheckError,
+	}
+
+	for _, probe := range container.Probes {
+		p := ProbeResp{
+			Type:       probe.Type,
+			Target:     probe.Target,
+			Port:       probe.Port,
+			Success:    probe.Success,
+			Latency:    probe.Latency,
+			StatusCode: probe.StatusCode,
+			Addresses:  probe.Addresses,
+			Status:     probe.Status,
+			Error:      probe.Error,
 		}
+		if !probe.CertExpiry.IsZero() {
+			p.CertExpiry = probe.CertExpiry.Format(time.DateTime)
+		}
+
+		resp.Probes = append(resp.Probes, p)
 	}
 
-	ctx.SuccessWithData(data)
+	if container.Gone() {
+		resp.GoneAt = container.GoneAt.Format(time.DateTime)
+	}
+
+	return resp
 }
@@ -0,0 +1,100 @@
+package containershandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/entity"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const defaultHistoryWindow = 24 * time.Hour
+
+// HistoryResp результат пинга из истории контейнера, содержит только поля, которые хранятся в ping_results
+type HistoryResp struct {
+	Key         string  `json:"key"`
+	IPAddress   string  `json:"ip_address"`
+	IsReachable bool    `json:"is_reachable"`
+	CheckedAt   string  `json:"checked_at"`
+	PacketsSent int     `json:"packets_sent"`
+	PacketsRecv int     `json:"packets_recv"`
+	PacketLoss  float64 `json:"packet_loss"`
+	MinRtt      float64 `json:"min_rtt"`
+	AvgRtt      float64 `json:"avg_rtt"`
+	MaxRtt      float64 `json:"max_rtt"`
+	StdDevRtt   float64 `json:"stddev_rtt"`
+	Jitter      float64 `json:"jitter"`
+	CheckError  string  `json:"check_error,omitempty"`
+}
+
+func (c *ContainersHandler) History(ctx *utilapi.APIContext) {
+	key := ctx.PathValue("key")
+
+	from, to, err := parseTimeRange(ctx.GetFromQuery("from"), ctx.GetFromQuery("to"), defaultHistoryWindow)
+	if err != nil {
+		ctx.Error("failed to parse time range", err)
+		ctx.WriteFailure(http.StatusBadRequest, "invalid time range")
+		return
+	}
+
+	results, err := c.containers.History(ctx, key, from, to)
+	if err != nil {
+		ctx.Error("failed to get container history", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	data := make([]HistoryResp, len(results))
+
+	for i, result := range results {
+		data[i] = newHistoryResp(result)
+	}
+
+	ctx.SuccessWithData(data)
+}
+
+func newHistoryResp(result entity.Container) HistoryResp {
+	return HistoryResp{
+		Key:         result.Key,
+		IPAddress:   result.IP,
+		IsReachable: result.IsReachable,
+		CheckedAt:   result.LastPing.Format(time.DateTime),
+		PacketsSent: result.PacketsSent,
+		PacketsRecv: result.PacketsRecv,
+		PacketLoss:  result.PacketLoss,
+		MinRtt:      result.MinRtt,
+		AvgRtt:      result.AvgRtt,
+		MaxRtt:      result.MaxRtt,
+		StdDevRtt:   result.StdDevRtt,
+		Jitter:      result.Jitter,
+		CheckError:  result.CheckError,
+	}
+}
+
+// parseTimeRange разбирает границы from и to в формате time.DateTime, при их отсутствии
+// to равно текущему времени, а from отстоит от to на window
+func parseTimeRange(fromStr, toStr string, window time.Duration) (time.Time, time.Time, error) {
+	to := time.Now().UTC()
+	if toStr != "" {
+		t, err := time.Parse(time.DateTime, toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = t
+	}
+
+	from := to.Add(-window)
+	if fromStr != "" {
+		t, err := time.Parse(time.DateTime, fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = t
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("from must be before to")
+	}
+
+	return from, to, nil
+}
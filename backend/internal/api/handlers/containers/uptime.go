@@ -0,0 +1,95 @@
+package containershandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type UptimeResp struct {
+	Key           string   `json:"key"`
+	From          string   `json:"from"`
+	To            string   `json:"to"`
+	Checks        int      `json:"checks"`
+	Up            int      `json:"up"`
+	Down          int      `json:"down"`
+	Unknown       int      `json:"unknown"`
+	Availability  *float64 `json:"availability"`
+	Outages       int      `json:"outages"`
+	MTTR          float64  `json:"mttr"`
+	LongestOutage float64  `json:"longest_outage"`
+	AvgRtt        float64  `json:"avg_rtt"`
+	MaxRtt        float64  `json:"max_rtt"`
+}
+
+func (c *ContainersHandler) Uptime(ctx *utilapi.APIContext) {
+	key := ctx.PathValue("key")
+
+	window := defaultHistoryWindow
+	if w := ctx.GetFromQuery("window"); w != "" {
+		var err error
+		window, err = parseWindow(w)
+		if err != nil {
+			ctx.Error("failed to parse window", err)
+			ctx.WriteFailure(http.StatusBadRequest, "invalid window")
+			return
+		}
+	}
+
+	from, to, err := parseTimeRange(ctx.GetFromQuery("from"), ctx.GetFromQuery("to"), window)
+	if err != nil {
+		ctx.Error("failed to parse time range", err)
+		ctx.WriteFailure(http.StatusBadRequest, "invalid time range")
+		return
+	}
+
+	uptime, err := c.containers.Uptime(ctx, key, from, to)
+	if err != nil {
+		ctx.Error("failed to calculate container uptime", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	ctx.SuccessWithData(UptimeResp{
+		Key:           key,
+		From:          uptime.From.Format(time.DateTime),
+		To:            uptime.To.Format(time.DateTime),
+		Checks:        uptime.Checks,
+		Up:            uptime.Up,
+		Down:          uptime.Down,
+		Unknown:       uptime.Unknown,
+		Availability:  uptime.Availability,
+		Outages:       uptime.Outages,
+		MTTR:          uptime.MTTR.Seconds(),
+		LongestOutage: uptime.LongestOutage.Seconds(),
+		AvgRtt:        uptime.AvgRtt,
+		MaxRtt:        uptime.MaxRtt,
+	})
+}
+
+// parseWindow разбирает длительность окна, помимо формата time.ParseDuration поддерживает дни, например 7d
+func parseWindow(w string) (time.Duration, error) {
+	var window time.Duration
+	if days, ok := strings.CutSuffix(w, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		window = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		window, err = time.ParseDuration(w)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if window <= 0 {
+		return 0, errors.New("window must be positive")
+	}
+
+	return window, nil
+}
@@ -0,0 +1,75 @@
+package eventshandler
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/pkg/contracts"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+// ProcessQueue сохраняет события жизненного цикла контейнеров из очереди событий RabbitMQ.
+// По отметке об удалении цели контейнер помечается удаленным, после падения, остановки или OOM -
+// недоступным
+func (e *EventsHandler) ProcessQueue(log *slog.Logger) {
+	msgs, err := e.rabbitMQ.Consume()
+	if err != nil {
+		log.Error("failed get events from rabbitmq", slog.Any("error", err))
+		return
+	}
+
+	for msg := range msgs {
+		var req contracts.ContainerEventReq
+
+		if err := json.Unmarshal(msg.Body, &req); err != nil {
+			log.Error("failed to decode RabbitMQ message", slog.Any("error", err))
+			continue
+		}
+
+		if !req.IsValid() {
+			log.Error("failed decode json", slog.Any("error", "invalid request"))
+			continue
+		}
+
+		for _, r := range req.Events {
+			at, err := time.Parse(time.DateTime, r.Time)
+			if err != nil {
+				log.Error("failed to parse event time", slog.String("key", r.Key), slog.Any("error", err))
+				continue
+			}
+
+			event := entity.ContainerEvent{
+				Key:         r.Key,
+				ContainerID: r.ContainerID,
+				Name:        r.Name,
+				Action:      r.Action,
+				ExitCode:    r.ExitCode,
+				OOMKilled:   r.OOMKilled,
+				Time:        at,
+			}
+
+			if _, err = e.events.Add(context.Background(), event); err != nil {
+				log.Error("failed to add event", slog.String("key", r.Key), slog.Any("error", err))
+				continue
+			}
+
+			switch event.Action {
+			case entity.EventRemoved:
+				if err = e.monitor.Remove(context.Background(), event.Key, event.Time); err != nil {
+					log.Error("failed to remove container", slog.String("key", r.Key), slog.Any("error", err))
+				}
+				continue
+			case entity.EventDie, entity.EventStop, entity.EventOOM:
+				if err = e.monitor.Stop(context.Background(), event.Key, event.Time); err != nil {
+					log.Error("failed to stop container", slog.String("key", r.Key), slog.Any("error", err))
+				}
+			}
+
+			if event.Crashed() {
+				log.Warn("container crashed", slog.String("key", event.Key), slog.Int("exit_code", event.ExitCode),
+					slog.Bool("oom_killed", event.OOMKilled))
+			}
+		}
+	}
+}
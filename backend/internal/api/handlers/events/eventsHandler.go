@@ -0,0 +1,22 @@
+package eventshandler
+
+import (
+	"app-pinger/backend/internal/usecase"
+	queue "app-pinger/pkg/queue"
+)
+
+type EventsHandler struct {
+	events    usecase.EventRepo
+	incidents usecase.IncidentRepo
+	monitor   *usecase.Monitor
+	rabbitMQ  queue.RabbitMQ
+}
+
+func NewEventsHandler(e usecase.EventRepo, i usecase.IncidentRepo, m *usecase.Monitor, r queue.RabbitMQ) *EventsHandler {
+	return &EventsHandler{
+		events:    e,
+		incidents: i,
+		monitor:   m,
+		rabbitMQ:  r,
+	}
+}
@@ -0,0 +1,194 @@
+package eventshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	storagemock "app-pinger/backend/internal/usecase/repo/mock"
+	"app-pinger/pkg/contracts"
+	mockqueue "app-pinger/pkg/queue/mock"
+	"context"
+	"encoding/json"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testKey = "app.web.1@app_default"
+
+func TestEventsHandler_Timeline(t *testing.T) {
+	at := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name  string
+		url   string
+		want  int
+		count int
+	}{
+		{
+			name:  "Valid (events and incidents)",
+			url:   "/container/" + testKey + "/timeline",
+			want:  http.StatusOK,
+			count: 4,
+		},
+		{
+			name:  "Valid (limit)",
+			url:   "/container/" + testKey + "/timeline?limit=2",
+			want:  http.StatusOK,
+			count: 2,
+		},
+		{
+			name:  "Valid (unknown container)",
+			url:   "/container/unknown/timeline",
+			want:  http.StatusOK,
+			count: 0,
+		},
+		{
+			name: "Invalid limit",
+			url:  "/container/" + testKey + "/timeline?limit=-1",
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := storagemock.NewMockEventRepo(
+				entity.ContainerEvent{Key: testKey, Action: entity.EventDie, ExitCode: 137, OOMKilled: true, Time: at},
+				entity.ContainerEvent{Key: testKey, Action: entity.EventStart, Time: at.Add(time.Minute)},
+			)
+			incidents := storagemock.NewMockIncidentRepo(
+				entity.Incident{ID: 1, Key: testKey, StartedAt: at.Add(time.Second), EndedAt: at.Add(2 * time.Minute)},
+			)
+			h := NewEventsHandler(events, incidents, nil, &mockqueue.MockRabbitMQ{})
+
+			r := utilapi.NewRouter(slog.Default())
+			r.Handle("GET /container/{key}/timeline", h.Timeline)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.want, w.Code)
+
+			if w.Code == http.StatusOK {
+				var resp []TimelineResp
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Len(t, resp, tt.count)
+
+				if tt.count == 4 {
+					require.Equal(t, entity.IncidentEnded, resp[0].Action)
+					require.Equal(t, entity.EventStart, resp[1].Action)
+					require.Equal(t, entity.IncidentStarted, resp[2].Action)
+					require.Equal(t, entity.EventDie, resp[3].Action)
+					require.True(t, resp[3].Crashed)
+					require.Equal(t, 137, resp[3].ExitCode)
+				}
+			}
+		})
+	}
+}
+
+func TestEventsHandler_ProcessQueue(t *testing.T) {
+	now := time.Now().Format(time.DateTime)
+
+	tests := []struct {
+		name   string
+		events []contracts.ContainerEvent
+		count  int
+		gone   bool
+		open   bool
+		status string
+	}{
+		{
+			name: "Valid events (container died)",
+			events: []contracts.ContainerEvent{
+				{Action: contracts.ActionDie, Time: now, ExitCode: 1, ContainerInfo: contracts.ContainerInfo{Key: testKey}},
+				{Action: contracts.ActionStop, Time: now, ContainerInfo: contracts.ContainerInfo{Key: testKey}},
+			},
+			count:  2,
+			status: entity.ContainerDown,
+		},
+		{
+			name: "Start does not change state",
+			events: []contracts.ContainerEvent{
+				{Action: contracts.ActionStart, Time: now, ContainerInfo: contracts.ContainerInfo{Key: testKey}},
+			},
+			count:  1,
+			status: entity.ContainerUp,
+		},
+		{
+			name: "Removed target",
+			events: []contracts.ContainerEvent{
+				{Action: contracts.ActionRemoved, Time: now, ContainerInfo: contracts.ContainerInfo{Key: testKey}},
+			},
+			count:  1,
+			gone:   true,
+			open:   true,
+			status: entity.ContainerGone,
+		},
+		{
+			name: "Invalid event time",
+			events: []contracts.ContainerEvent{
+				{Action: contracts.ActionDie, Time: "yesterday", ContainerInfo: contracts.ContainerInfo{Key: testKey}},
+			},
+			count:  0,
+			status: entity.ContainerUp,
+		},
+		{
+			name: "Invalid request (no key)",
+			events: []contracts.ContainerEvent{
+				{Action: contracts.ActionStart, Time: now},
+			},
+			count:  0,
+			status: entity.ContainerUp,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(contracts.ContainerEventReq{Events: tt.events})
+			require.NoError(t, err)
+
+			msgs := make(chan amqp091.Delivery, 1)
+			msgs <- amqp091.Delivery{Body: body}
+			close(msgs)
+
+			mockRabbit := &mockqueue.MockRabbitMQ{}
+			mockRabbit.On("Consume").Return(msgs, nil)
+
+			events := storagemock.NewMockEventRepo()
+			containers := storagemock.NewMockRepo(entity.Container{
+				IP:            "172.10.0.2",
+				IsReachable:   true,
+				LastPing:      time.Now().Add(-time.Hour),
+				ContainerInfo: entity.ContainerInfo{Key: testKey, Name: "app-web-1"},
+			})
+			incidents := storagemock.NewMockIncidentRepo()
+			if tt.open {
+				incidents = storagemock.NewMockIncidentRepo(entity.Incident{ID: 1, Key: testKey, StartedAt: time.Now()})
+			}
+			monitor := usecase.NewMonitor(containers, incidents, nil, nil, nil, nil)
+			h := NewEventsHandler(events, incidents, monitor, mockRabbit)
+
+			h.ProcessQueue(slog.Default())
+
+			stored, err := events.GetAll(context.Background(), testKey, maxTimelineLimit)
+			require.NoError(t, err)
+			require.Len(t, stored, tt.count)
+
+			// упавший контейнер становится недоступным с открытым инцидентом, удаленный помечается gone
+			all, err := containers.GetAll(context.Background(), usecase.ContainerFilter{})
+			require.NoError(t, err)
+			require.Equal(t, tt.gone, all[0].Gone())
+			require.Equal(t, tt.status, all[0].Status())
+			require.Equal(t, "app-web-1", all[0].Name)
+
+			incident, err := incidents.GetOpen(context.Background(), testKey)
+			require.NoError(t, err)
+			require.Equal(t, tt.status == entity.ContainerDown, incident != nil)
+		})
+	}
+}
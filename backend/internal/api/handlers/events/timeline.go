@@ -0,0 +1,71 @@
+package eventshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultTimelineLimit = 100
+	maxTimelineLimit     = 1000
+)
+
+type TimelineResp struct {
+	Time      string `json:"time"`
+	Kind      string `json:"kind"`
+	Action    string `json:"action"`
+	ExitCode  int    `json:"exit_code,omitempty"`
+	OOMKilled bool   `json:"oom_killed,omitempty"`
+	Crashed   bool   `json:"crashed,omitempty"`
+}
+
+// Timeline возвращает хронологию контейнера: события жизненного цикла вместе с началом
+// и концом инцидентов, от новых к старым
+func (e *EventsHandler) Timeline(ctx *utilapi.APIContext) {
+	key := ctx.PathValue("key")
+
+	limit := defaultTimelineLimit
+	if l := ctx.GetFromQuery("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n <= 0 || n > maxTimelineLimit {
+			ctx.Error("failed to parse limit", errors.New("limit out of range"))
+			ctx.WriteFailure(http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = n
+	}
+
+	events, err := e.events.GetAll(ctx, key, limit)
+	if err != nil {
+		ctx.Error("failed to get container events", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	incidents, err := e.incidents.GetAll(ctx, usecase.IncidentFilter{Key: key})
+	if err != nil {
+		ctx.Error("failed to get container incidents", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	timeline := usecase.BuildTimeline(events, incidents, limit)
+	data := make([]TimelineResp, len(timeline))
+
+	for i, entry := range timeline {
+		data[i] = TimelineResp{
+			Time:      entry.Time.Format(time.DateTime),
+			Kind:      entry.Kind,
+			Action:    entry.Action,
+			ExitCode:  entry.ExitCode,
+			OOMKilled: entry.OOMKilled,
+			Crashed:   entry.Crashed,
+		}
+	}
+
+	ctx.SuccessWithData(data)
+}
@@ -0,0 +1,61 @@
+package incidentshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"errors"
+	"net/http"
+	"time"
+)
+
+type IncidentResp struct {
+	ID        int64   `json:"id"`
+	Key       string  `json:"key"`
+	IPAddress string  `json:"ip_address"`
+	State     string  `json:"state"`
+	StartedAt string  `json:"started_at"`
+	EndedAt   string  `json:"ended_at,omitempty"`
+	Duration  float64 `json:"duration"`
+}
+
+func (i *IncidentsHandler) GetAll(ctx *utilapi.APIContext) {
+	filter := usecase.IncidentFilter{
+		Key:   ctx.GetFromQuery("key"),
+		IP:    ctx.GetFromQuery("ip"),
+		State: ctx.GetFromQuery("state"),
+	}
+
+	if filter.State != "" && filter.State != entity.IncidentOpen && filter.State != entity.IncidentClosed {
+		ctx.Error("failed to parse filter", errors.New("unknown incident state"))
+		ctx.WriteFailure(http.StatusBadRequest, "invalid state")
+		return
+	}
+
+	incidents, err := i.incidents.GetAll(ctx, filter)
+	if err != nil {
+		ctx.Error("failed to get incidents", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	now := time.Now().UTC()
+	data := make([]IncidentResp, len(incidents))
+
+	for idx, incident := range incidents {
+		data[idx] = IncidentResp{
+			ID:        incident.ID,
+			Key:       incident.Key,
+			IPAddress: incident.IP,
+			State:     incident.State(),
+			StartedAt: incident.StartedAt.Format(time.DateTime),
+			Duration:  incident.Duration(now).Seconds(),
+		}
+
+		if !incident.IsOpen() {
+			data[idx].EndedAt = incident.EndedAt.Format(time.DateTime)
+		}
+	}
+
+	ctx.SuccessWithData(data)
+}
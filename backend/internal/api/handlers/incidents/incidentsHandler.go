@@ -0,0 +1,15 @@
+package incidentshandler
+
+import (
+	"app-pinger/backend/internal/usecase"
+)
+
+type IncidentsHandler struct {
+	incidents usecase.IncidentRepo
+}
+
+func NewIncidentsHandler(i usecase.IncidentRepo) *IncidentsHandler {
+	return &IncidentsHandler{
+		incidents: i,
+	}
+}
@@ -0,0 +1,76 @@
+package incidentshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/entity"
+	storagemock "app-pinger/backend/internal/usecase/repo/mock"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIncidentsHandler_GetAll(t *testing.T) {
+	startedAt := time.Now().UTC().Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		url   string
+		want  interface{}
+		count int
+	}{
+		{
+			name:  "Valid (all incidents)",
+			url:   "/incidents",
+			want:  http.StatusOK,
+			count: 3,
+		},
+		{
+			name:  "Valid (open incidents)",
+			url:   "/incidents?state=open",
+			want:  http.StatusOK,
+			count: 1,
+		},
+		{
+			name:  "Valid (closed incidents of container)",
+			url:   "/incidents?state=closed&ip=192.168.0.1",
+			want:  http.StatusOK,
+			count: 1,
+		},
+		{
+			name: "Invalid state",
+			url:  "/incidents?state=unknown",
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := storagemock.NewMockIncidentRepo(
+				entity.Incident{ID: 1, IP: "192.168.0.1", StartedAt: startedAt, EndedAt: startedAt.Add(time.Minute)},
+				entity.Incident{ID: 2, IP: "192.168.0.2", StartedAt: startedAt, EndedAt: startedAt.Add(time.Minute)},
+				entity.Incident{ID: 3, IP: "192.168.0.1", StartedAt: startedAt.Add(30 * time.Minute)},
+			)
+			h := NewIncidentsHandler(mockRepo)
+
+			r := utilapi.NewRouter(slog.Default())
+			r.Handle("GET /incidents", h.GetAll)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.want, w.Code)
+
+			if w.Code == http.StatusOK {
+				var resp []IncidentResp
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Len(t, resp, tt.count)
+			}
+		})
+	}
+}
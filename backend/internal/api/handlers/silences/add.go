@@ -0,0 +1,84 @@
+package silenceshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/entity"
+	"errors"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+type SilenceAddReq struct {
+	Name     string `json:"container_name"`
+	IP       string `json:"ip_address"`
+	Label    string `json:"label"`
+	Network  string `json:"network"`
+	StartsAt string `json:"starts_at"`
+	EndsAt   string `json:"ends_at"`
+	Comment  string `json:"comment"`
+}
+
+func (req *SilenceAddReq) IsValid() bool {
+	hasSelector := req.Name != "" || req.IP != "" || req.Label != "" || req.Network != ""
+
+	return hasSelector && utf8.RuneCountInString(req.EndsAt) > 0
+}
+
+type SilenceAddResp struct {
+	ID int64 `json:"id"`
+}
+
+func (s *SilencesHandler) Add(ctx *utilapi.APIContext) {
+	var req SilenceAddReq
+
+	if err := ctx.Decode(&req); err != nil {
+		ctx.Error("failed to decode request", err)
+		ctx.WriteFailure(http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	now := time.Now().UTC()
+
+	silence := entity.Silence{
+		Name:      req.Name,
+		IP:        req.IP,
+		Label:     req.Label,
+		Network:   req.Network,
+		StartsAt:  now,
+		Comment:   req.Comment,
+		CreatedAt: now,
+	}
+
+	var err error
+	if req.StartsAt != "" {
+		silence.StartsAt, err = time.Parse(time.DateTime, req.StartsAt)
+		if err != nil {
+			ctx.Error("failed to parse starts_at", err)
+			ctx.WriteFailure(http.StatusBadRequest, "invalid starts_at")
+			return
+		}
+	}
+
+	silence.EndsAt, err = time.Parse(time.DateTime, req.EndsAt)
+	if err != nil {
+		ctx.Error("failed to parse ends_at", err)
+		ctx.WriteFailure(http.StatusBadRequest, "invalid ends_at")
+		return
+	}
+
+	if !silence.StartsAt.Before(silence.EndsAt) {
+		ctx.Error("invalid silence period", errors.New("starts_at must be before ends_at"))
+		ctx.WriteFailure(http.StatusBadRequest, "invalid period")
+		return
+	}
+
+	id, err := s.silences.Add(ctx, silence)
+	if err != nil {
+		ctx.Error("failed to add silence", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	ctx.SuccessWithData(SilenceAddResp{ID: id})
+}
@@ -0,0 +1,36 @@
+package silenceshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+type SilenceDeleteResp struct {
+	Text string `json:"msg"`
+}
+
+func (s *SilencesHandler) Delete(ctx *utilapi.APIContext) {
+	id, err := strconv.ParseInt(ctx.PathValue("id"), 10, 64)
+	if err != nil {
+		ctx.Error("failed to parse silence id", err)
+		ctx.WriteFailure(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	deleted, err := s.silences.Delete(ctx, id)
+	if err != nil {
+		ctx.Error("failed to delete silence", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	if !deleted {
+		ctx.Error("failed to delete silence", errors.New("silence not found"))
+		ctx.WriteFailure(http.StatusNotFound, "silence not found")
+		return
+	}
+
+	ctx.SuccessWithData(SilenceDeleteResp{Text: "silence deleted"})
+}
@@ -0,0 +1,58 @@
+package silenceshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/entity"
+	"net/http"
+	"time"
+)
+
+type SilenceResp struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"container_name,omitempty"`
+	IP        string `json:"ip_address,omitempty"`
+	Label     string `json:"label,omitempty"`
+	Network   string `json:"network,omitempty"`
+	StartsAt  string `json:"starts_at"`
+	EndsAt    string `json:"ends_at"`
+	Comment   string `json:"comment,omitempty"`
+	Active    bool   `json:"active"`
+	CreatedAt string `json:"created_at"`
+}
+
+func (s *SilencesHandler) GetAll(ctx *utilapi.APIContext) {
+	now := time.Now().UTC()
+
+	var silences []entity.Silence
+	var err error
+
+	if ctx.GetFromQuery("active") == "true" {
+		silences, err = s.silences.GetActive(ctx, now)
+	} else {
+		silences, err = s.silences.GetAll(ctx)
+	}
+	if err != nil {
+		ctx.Error("failed to get silences", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	data := make([]SilenceResp, len(silences))
+
+	for i, silence := range silences {
+		data[i] = SilenceResp{
+			ID:        silence.ID,
+			Name:      silence.Name,
+			IP:        silence.IP,
+			Label:     silence.Label,
+			Network:   silence.Network,
+			StartsAt:  silence.StartsAt.Format(time.DateTime),
+			EndsAt:    silence.EndsAt.Format(time.DateTime),
+			Comment:   silence.Comment,
+			Active:    silence.IsActive(now),
+			CreatedAt: silence.CreatedAt.Format(time.DateTime),
+		}
+	}
+
+	ctx.SuccessWithData(data)
+}
@@ -0,0 +1,15 @@
+package silenceshandler
+
+import (
+	"app-pinger/backend/internal/usecase"
+)
+
+type SilencesHandler struct {
+	silences usecase.SilenceRepo
+}
+
+func NewSilencesHandler(s usecase.SilenceRepo) *SilencesHandler {
+	return &SilencesHandler{
+		silences: s,
+	}
+}
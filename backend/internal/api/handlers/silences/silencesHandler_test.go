@@ -0,0 +1,186 @@
+package silenceshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"app-pinger/backend/internal/entity"
+	storagemock "app-pinger/backend/internal/usecase/repo/mock"
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSilencesHandler_Add(t *testing.T) {
+	tests := []struct {
+		name string
+		req  SilenceAddReq
+		want interface{}
+	}{
+		{
+			name: "Valid silence (starts now)",
+			req: SilenceAddReq{
+				IP:     "192.168.0.1",
+				EndsAt: time.Now().UTC().Add(time.Hour).Format(time.DateTime),
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "Valid silence (planned)",
+			req: SilenceAddReq{
+				Name:     "backend",
+				StartsAt: "2025-02-08 01:00:00",
+				EndsAt:   "2025-02-08 02:00:00",
+				Comment:  "nightly deploy",
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "Invalid silence (no selector)",
+			req: SilenceAddReq{
+				EndsAt: "2025-02-08 02:00:00",
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid silence (no end)",
+			req: SilenceAddReq{
+				IP: "192.168.0.1",
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid silence (ends before start)",
+			req: SilenceAddReq{
+				IP:       "192.168.0.1",
+				StartsAt: "2025-02-08 02:00:00",
+				EndsAt:   "2025-02-08 01:00:00",
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid silence (not data)",
+			req: SilenceAddReq{
+				IP:     "192.168.0.1",
+				EndsAt: "tomorrow",
+			},
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := storagemock.NewMockSilenceRepo()
+			h := NewSilencesHandler(mockRepo)
+
+			r := utilapi.NewRouter(slog.Default())
+			r.Handle("POST /silences", h.Add)
+
+			body, _ := json.Marshal(tt.req)
+			req := httptest.NewRequest(http.MethodPost, "/silences", bytes.NewReader(body))
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.want, w.Code)
+
+			silences, err := mockRepo.GetAll(req.Context())
+			require.NoError(t, err)
+
+			if tt.want == http.StatusOK {
+				require.Len(t, silences, 1)
+			} else {
+				require.Empty(t, silences)
+			}
+		})
+	}
+}
+
+func TestSilencesHandler_GetAll(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name  string
+		url   string
+		count int
+	}{
+		{
+			name:  "All silences",
+			url:   "/silences",
+			count: 2,
+		},
+		{
+			name:  "Active silences",
+			url:   "/silences?active=true",
+			count: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := storagemock.NewMockSilenceRepo(
+				entity.Silence{ID: 1, IP: "192.168.0.1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+				entity.Silence{ID: 2, Name: "backend", StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)},
+			)
+			h := NewSilencesHandler(mockRepo)
+
+			r := utilapi.NewRouter(slog.Default())
+			r.Handle("GET /silences", h.GetAll)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var resp []SilenceResp
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Len(t, resp, tt.count)
+		})
+	}
+}
+
+func TestSilencesHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want interface{}
+	}{
+		{
+			name: "Valid delete",
+			url:  "/silences/1",
+			want: http.StatusOK,
+		},
+		{
+			name: "Not found",
+			url:  "/silences/2",
+			want: http.StatusNotFound,
+		},
+		{
+			name: "Invalid id",
+			url:  "/silences/first",
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := storagemock.NewMockSilenceRepo(entity.Silence{ID: 1, IP: "192.168.0.1"})
+			h := NewSilencesHandler(mockRepo)
+
+			r := utilapi.NewRouter(slog.Default())
+			r.Handle("DELETE /silences/{id}", h.Delete)
+
+			req := httptest.NewRequest(http.MethodDelete, tt.url, nil)
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.want, w.Code)
+		})
+	}
+}
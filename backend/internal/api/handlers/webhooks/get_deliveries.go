@@ -0,0 +1,48 @@
+package webhookshandler
+
+import (
+	"app-pinger/backend/internal/api/utilapi"
+	"net/http"
+	"time"
+)
+
+type DeliveryResp struct {
+	ID         int64  `json:"id"`
+	Target     string `json:"target"`
+	URL        string `json:"url"`
+	Event      string `json:"event"`
+	IPAddress  string `json:"ip_address"`
+	Success    bool   `json:"success"`
+	Attempts   int    `json:"attempts"`
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+func (w *WebhooksHandler) GetDeliveries(ctx *utilapi.APIContext) {
+	deliveries, err := w.deliveries.GetAll(ctx, ctx.GetFromQuery("target"))
+	if err != nil {
+		ctx.Error("failed to get webhook deliveries", err)
+		ctx.WriteFailure(http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	data := make([]DeliveryResp, len(deliveries))
+
+	for i, d := range deliveries {
+		data[i] = DeliveryResp{
+			ID:         d.ID,
+			Target:     d.Target,
+			URL:        d.URL,
+			Event:      d.Event,
+			IPAddress:  d.IP,
+			Success:    d.Success,
+			Attempts:   d.Attempts,
+			StatusCode: d.StatusCode,
+			Error:      d.Error,
+			CreatedAt:  d.CreatedAt.Format(time.DateTime),
+		}
+	}
+
+	ctx.SuccessWithData(data)
+}
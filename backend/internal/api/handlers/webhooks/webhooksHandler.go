@@ -0,0 +1,15 @@
+package webhookshandler
+
+import (
+	"app-pinger/backend/internal/usecase"
+)
+
+type WebhooksHandler struct {
+	deliveries usecase.DeliveryRepo
+}
+
+func NewWebhooksHandler(d usecase.DeliveryRepo) *WebhooksHandler {
+	return &WebhooksHandler{
+		deliveries: d,
+	}
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"app-pinger/pkg/config"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 )
 
@@ -14,6 +16,10 @@ type Config struct {
 	Timeout      time.Duration `env:"TIMEOUT"`
 	IdleTimeout  time.Duration `env:"IDLE_TIMEOUT"`
 	LogLevel     string        `env:"BACKEND_LOG_LEVEL"`
+	FlapWindow   time.Duration `env:"FLAPPING_WINDOW" env-default:"10m"`
+	FlapCount    int           `env:"FLAPPING_THRESHOLD" env-default:"5"`
+	GoneTTL      time.Duration `env:"GONE_RETENTION" env-default:"24h"`
+	GonePurge    time.Duration `env:"GONE_PURGE_INTERVAL" env-default:"10m"`
 	DB           config.DataBase
 	RabbitMQ     config.RabbitMQ
 }
@@ -27,5 +33,27 @@ func ConfigLoad() *Config {
 	cfg.RabbitMQPath = cfg.RabbitMQ.NewRabbitMQPath()
 	cfg.Addr = fmt.Sprintf("%s:%s", cfg.Addr, cfg.Port)
 
+	if err := cfg.validate(); err != nil {
+		log.Fatalf("invalid config: %v", err)
+	}
+
 	return &cfg
 }
+
+func (c *Config) validate() error {
+	// при пороге меньше двух смен состояния любой контейнер сразу считается нестабильным
+	if c.FlapCount < 2 {
+		return errors.New("FLAPPING_THRESHOLD must be at least 2")
+	}
+	if c.FlapWindow <= 0 {
+		return errors.New("FLAPPING_WINDOW must be positive")
+	}
+	if c.GoneTTL <= 0 {
+		return errors.New("GONE_RETENTION must be positive")
+	}
+	if c.GonePurge <= 0 {
+		return errors.New("GONE_PURGE_INTERVAL must be positive")
+	}
+
+	return nil
+}
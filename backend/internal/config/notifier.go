@@ -0,0 +1,98 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"log"
+	"net/url"
+	"os"
+	"time"
+)
+
+type Webhook struct {
+	Name   string `yaml:"name"`
+	URL    string `yaml:"url"`
+	Secret string `yaml:"secret"`
+}
+
+type NotifierConfig struct {
+	Retries    int           `yaml:"retries"`
+	Backoff    time.Duration `yaml:"backoff"`
+	MaxBackoff time.Duration `yaml:"max_backoff"`
+	Timeout    time.Duration `yaml:"timeout"`
+	QueueSize  int           `yaml:"queue_size"`
+	Webhooks   []Webhook     `yaml:"webhooks"`
+}
+
+// LoadNotifierConfig загружает настройки webhook-уведомлений, файл необязателен - без него
+// уведомления не отправляются
+func LoadNotifierConfig() *NotifierConfig {
+	cfg := NotifierConfig{
+		Retries:    3,
+		Backoff:    time.Second,
+		MaxBackoff: 30 * time.Second,
+		Timeout:    5 * time.Second,
+		QueueSize:  100,
+	}
+
+	yamlFile, err := os.ReadFile("notifier_config.yaml")
+	if errors.Is(err, os.ErrNotExist) {
+		log.Println("no notifier_config.yaml found, webhook notifications are disabled")
+		return &cfg
+	}
+	if err != nil {
+		log.Fatalf("failed to read notifier_config.yaml: %v", err)
+	}
+
+	err = yaml.Unmarshal(yamlFile, &cfg)
+	if err != nil {
+		log.Fatalf("unmarshal failed with error: %v", err)
+	}
+
+	if err = cfg.validate(); err != nil {
+		log.Fatalf("invalid notifier_config.yaml: %v", err)
+	}
+
+	return &cfg
+}
+
+func (c *NotifierConfig) validate() error {
+	if c.Retries < 0 {
+		return errors.New("retries must not be negative")
+	}
+	if c.Backoff <= 0 {
+		return errors.New("backoff must be positive")
+	}
+	if c.MaxBackoff < c.Backoff {
+		return errors.New("max_backoff must not be less than backoff")
+	}
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	if c.QueueSize <= 0 {
+		return errors.New("queue_size must be positive")
+	}
+
+	names := make(map[string]struct{}, len(c.Webhooks))
+
+	for i, webhook := range c.Webhooks {
+		if webhook.Name == "" {
+			return fmt.Errorf("webhook %d: empty name", i)
+		}
+		if _, ok := names[webhook.Name]; ok {
+			return fmt.Errorf("webhook %s: duplicate name", webhook.Name)
+		}
+		names[webhook.Name] = struct{}{}
+
+		u, err := url.Parse(webhook.URL)
+		if err != nil {
+			return fmt.Errorf("webhook %s: invalid url: %w", webhook.Name, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook %s: url must be an absolute http or https url", webhook.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNotifierConfig_validate(t *testing.T) {
+	valid := func() NotifierConfig {
+		return NotifierConfig{
+			Retries:    3,
+			Backoff:    time.Second,
+			MaxBackoff: 30 * time.Second,
+			Timeout:    5 * time.Second,
+			QueueSize:  100,
+			Webhooks:   []Webhook{{Name: "ops", URL: "https://hooks.example.com/pinger", Secret: "s"}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *NotifierConfig)
+		valid  bool
+	}{
+		{
+			name:   "Valid",
+			modify: func(c *NotifierConfig) {},
+			valid:  true,
+		},
+		{
+			name:   "Valid (no retries, no webhooks)",
+			modify: func(c *NotifierConfig) { c.Retries = 0; c.Webhooks = nil },
+			valid:  true,
+		},
+		{
+			name:   "Negative retries",
+			modify: func(c *NotifierConfig) { c.Retries = -1 },
+		},
+		{
+			name:   "Zero backoff",
+			modify: func(c *NotifierConfig) { c.Backoff = 0 },
+		},
+		{
+			name:   "Max backoff less than backoff",
+			modify: func(c *NotifierConfig) { c.MaxBackoff = time.Millisecond },
+		},
+		{
+			name:   "Zero timeout",
+			modify: func(c *NotifierConfig) { c.Timeout = 0 },
+		},
+		{
+			name:   "Zero queue size",
+			modify: func(c *NotifierConfig) { c.QueueSize = 0 },
+		},
+		{
+			name:   "Empty name",
+			modify: func(c *NotifierConfig) { c.Webhooks[0].Name = "" },
+		},
+		{
+			name: "Duplicate name",
+			modify: func(c *NotifierConfig) {
+				c.Webhooks = append(c.Webhooks, Webhook{Name: "ops", URL: "http://other.example.com"})
+			},
+		},
+		{
+			name:   "Empty url",
+			modify: func(c *NotifierConfig) { c.Webhooks[0].URL = "" },
+		},
+		{
+			name:   "Relative url",
+			modify: func(c *NotifierConfig) { c.Webhooks[0].URL = "/pinger" },
+		},
+		{
+			name:   "Unsupported scheme",
+			modify: func(c *NotifierConfig) { c.Webhooks[0].URL = "ftp://hooks.example.com" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(&cfg)
+
+			err := cfg.validate()
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
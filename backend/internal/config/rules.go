@@ -0,0 +1,99 @@
+package config
+
+import (
+	"app-pinger/backend/internal/entity"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"log"
+	"os"
+	"time"
+)
+
+type AlertRule struct {
+	Name         string        `yaml:"name"`
+	Type         string        `yaml:"type"`
+	Consecutive  int           `yaml:"consecutive"`
+	ResolveAfter int           `yaml:"resolve_after"`
+	Threshold    float64       `yaml:"threshold"`
+	Window       time.Duration `yaml:"window"`
+}
+
+type RulesConfig struct {
+	Rules []AlertRule `yaml:"rules"`
+}
+
+// LoadRulesConfig загружает правила алертов, файл необязателен - без него алерты не вычисляются
+func LoadRulesConfig() *RulesConfig {
+	var cfg RulesConfig
+
+	yamlFile, err := os.ReadFile("rules_config.yaml")
+	if errors.Is(err, os.ErrNotExist) {
+		log.Println("no rules_config.yaml found, alert rules are disabled")
+		return &cfg
+	}
+	if err != nil {
+		log.Fatalf("failed to read rules_config.yaml: %v", err)
+	}
+
+	err = yaml.Unmarshal(yamlFile, &cfg)
+	if err != nil {
+		log.Fatalf("unmarshal failed with error: %v", err)
+	}
+
+	if err = cfg.validate(); err != nil {
+		log.Fatalf("invalid rules_config.yaml: %v", err)
+	}
+
+	return &cfg
+}
+
+func (c *RulesConfig) validate() error {
+	names := make(map[string]struct{}, len(c.Rules))
+
+	for i, rule := range c.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d: empty name", i)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("rule %s: duplicate name", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+
+		switch rule.Type {
+		case entity.RuleUnreachable:
+			if rule.Consecutive < 1 {
+				return fmt.Errorf("rule %s: consecutive must be positive", rule.Name)
+			}
+		case entity.RulePacketLoss, entity.RuleLatency:
+			if rule.Window <= 0 {
+				return fmt.Errorf("rule %s: window must be positive", rule.Name)
+			}
+			if rule.Threshold < 0 {
+				return fmt.Errorf("rule %s: threshold must not be negative", rule.Name)
+			}
+		default:
+			return fmt.Errorf("rule %s: unknown type %q", rule.Name, rule.Type)
+		}
+	}
+
+	return nil
+}
+
+// AlertRules возвращает правила алертов, resolve_after по умолчанию равен 1
+func (c *RulesConfig) AlertRules() []entity.AlertRule {
+	rules := make([]entity.AlertRule, len(c.Rules))
+
+	for i, rule := range c.Rules {
+		rules[i] = entity.AlertRule{
+			Name:         rule.Name,
+			Type:         rule.Type,
+			Consecutive:  rule.Consecutive,
+			ResolveAfter: max(rule.ResolveAfter, 1),
+			Threshold:    rule.Threshold,
+			Window:       rule.Window,
+		}
+	}
+
+	return rules
+}
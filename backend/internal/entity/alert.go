@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+const (
+	// RuleUnreachable срабатывает после Consecutive недоступных проверок подряд и сбрасывается
+	// после ResolveAfter доступных проверок подряд
+	RuleUnreachable = "unreachable"
+	// RulePacketLoss срабатывает, если средняя потеря пакетов за Window больше Threshold процентов
+	RulePacketLoss = "packet_loss"
+	// RuleLatency срабатывает, если средний RTT за Window больше Threshold миллисекунд
+	RuleLatency = "latency"
+)
+
+const (
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+)
+
+const (
+	NotificationAlertFiring   = "alert_firing"
+	NotificationAlertResolved = "alert_resolved"
+)
+
+type AlertRule struct {
+	Name         string
+	Type         string
+	Consecutive  int
+	ResolveAfter int
+	Threshold    float64
+	Window       time.Duration
+}
+
+// Alert сработавшее правило для контейнера, ResolvedAt равен нулю, пока алерт активен
+type Alert struct {
+	ID         int64
+	Rule       string
+	Key        string
+	IP         string
+	Value      float64
+	StartedAt  time.Time
+	ResolvedAt time.Time
+}
+
+func (a Alert) State() string {
+	if a.ResolvedAt.IsZero() {
+		return AlertFiring
+	}
+
+	return AlertResolved
+}
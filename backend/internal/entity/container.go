@@ -2,8 +2,126 @@ package entity
 
 import "time"
 
+// PingStats статистика пинга, время задержек указано в миллисекундах
+type PingStats struct {
+	PacketsSent int
+	PacketsRecv int
+	PacketLoss  float64
+	MinRtt      float64
+	AvgRtt      float64
+	MaxRtt      float64
+	StdDevRtt   float64
+	Jitter      float64
+}
+
+// ContainerInfo данные контейнера в Docker. Key - стабильный идентификатор контейнера в сети,
+// который не меняется при пересоздании контейнера (compose-сервис и сеть)
+type ContainerInfo struct {
+	Key         string
+	ContainerID string
+	Name        string
+	Image       string
+	Project     string
+	Service     string
+	Network     string
+	Labels      map[string]string
+}
+
+// Probe результат проверки сервиса контейнера (TCP-подключения к порту, HTTP-запроса, DNS-запроса,
+// gRPC health check), Latency указана в миллисекундах. CertExpiry равен нулю, если сертификат
+// не проверялся, Addresses - ответ DNS-сервера, Status - состояние gRPC-сервиса
+type Probe struct {
+	Type       string
+	Target     string
+	Port       int
+	Success    bool
+	Latency    float64
+	StatusCode int
+	CertExpiry time.Time
+	Addresses  []string
+	Status     string
+	Error      string
+}
+
+// Состояния HEALTHCHECK контейнера в Docker
+const (
+	HealthStarting  = "starting"
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+)
+
+// Общий статус контейнера с учетом доступности и HEALTHCHECK
+const (
+	ContainerUp          = "up"
+	ContainerStarting    = "starting"
+	ContainerUnhealthy   = "unhealthy"
+	ContainerDown        = "down"
+	ContainerGone        = "gone"
+	ContainerUnknown     = "unknown"
+	ContainerMaintenance = "maintenance"
+)
+
+// Health состояние HEALTHCHECK контейнера по данным Docker, пустой Status означает,
+// что проверка не настроена. Output - вывод последней проверки
+type Health struct {
+	Status        string
+	FailingStreak int
+	Output        string
+}
+
+// Container последний результат пинга контейнера. GoneAt - время, когда pinger сообщил
+// об удалении цели, нулевое значение означает, что цель проверяется. CheckError - причина,
+// по которой pinger не смог выполнить пинг. Family - семейство IP (ipv4, ipv6)
 type Container struct {
-	IP          string
-	IsReachable bool
-	LastPing    time.Time
+	IP            string
+	Family        string
+	IsReachable   bool
+	LastPing      time.Time
+	Flapping      bool
+	InMaintenance bool
+	ContainerInfo
+	PingStats
+	Probes     []Probe
+	Health     Health
+	GoneAt     time.Time
+	CheckError string
+}
+
+// Gone сообщает, что pinger больше не проверяет контейнер
+func (c Container) Gone() bool {
+	return !c.GoneAt.IsZero()
+}
+
+// Status возвращает общий статус контейнера: удаленный контейнер - gone, недоступный - down
+// (или unknown, если pinger не смог его проверить, и maintenance в окне обслуживания),
+// доступный - по состоянию HEALTHCHECK
+// (например, unhealthy, если контейнер пингуется, но Docker считает его нездоровым)
+func (c Container) Status() string {
+	if c.Gone() {
+		return ContainerGone
+	}
+
+	if !c.IsReachable {
+		switch {
+		case c.Unknown():
+			return ContainerUnknown
+		case c.InMaintenance:
+			return ContainerMaintenance
+		}
+		return ContainerDown
+	}
+
+	switch c.Health.Status {
+	case HealthUnhealthy:
+		return ContainerUnhealthy
+	case HealthStarting:
+		return ContainerStarting
+	}
+
+	return ContainerUp
+}
+
+// Unknown сообщает, что pinger не смог проверить недоступный контейнер, и его состояние неизвестно
+func (c Container) Unknown() bool {
+	return !c.IsReachable && c.CheckError != ""
 }
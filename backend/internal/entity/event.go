@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Действия жизненного цикла контейнера. EventRemoved - цель больше не проверяется pinger'ом:
+// контейнер удален, отключен от сети или исключен фильтром
+const (
+	EventStart   = "start"
+	EventStop    = "stop"
+	EventDie     = "die"
+	EventOOM     = "oom"
+	EventRemoved = "removed"
+)
+
+// ContainerEvent событие жизненного цикла контейнера, полученное от pinger
+type ContainerEvent struct {
+	ID          int64
+	Key         string
+	ContainerID string
+	Name        string
+	Action      string
+	ExitCode    int
+	OOMKilled   bool
+	Time        time.Time
+}
+
+// Crashed сообщает, что контейнер завершился аварийно: с ненулевым кодом выхода или по OOM
+func (e ContainerEvent) Crashed() bool {
+	return e.Action == EventOOM || e.Action == EventDie && (e.ExitCode != 0 || e.OOMKilled)
+}
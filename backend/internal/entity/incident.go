@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+const (
+	IncidentOpen   = "open"
+	IncidentClosed = "closed"
+)
+
+// Incident период недоступности контейнера, EndedAt равен нулю, пока инцидент открыт
+type Incident struct {
+	ID        int64
+	Key       string
+	IP        string
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+func (i Incident) IsOpen() bool {
+	return i.EndedAt.IsZero()
+}
+
+func (i Incident) State() string {
+	if i.IsOpen() {
+		return IncidentOpen
+	}
+
+	return IncidentClosed
+}
+
+// Duration возвращает длительность инцидента, для открытого инцидента - до момента now
+func (i Incident) Duration(now time.Time) time.Duration {
+	if i.IsOpen() {
+		return now.Sub(i.StartedAt)
+	}
+
+	return i.EndedAt.Sub(i.StartedAt)
+}
@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+const (
+	StateReachable   = "reachable"
+	StateUnreachable = "unreachable"
+	StateFlapping    = "flapping"
+)
+
+const (
+	NotificationStateChanged    = "state_changed"
+	NotificationFlappingStarted = "flapping_started"
+	NotificationFlappingStopped = "flapping_stopped"
+)
+
+// Notification событие для внешних систем оповещения
+type Notification struct {
+	Type      string
+	Key       string
+	Name      string
+	IP        string
+	OldState  string
+	NewState  string
+	Rule      string
+	Value     float64
+	Timestamp time.Time
+	PingStats
+}
+
+// Delivery запись журнала доставки уведомления до webhook-получателя
+type Delivery struct {
+	ID         int64
+	Target     string
+	URL        string
+	Event      string
+	IP         string
+	Success    bool
+	Attempts   int
+	StatusCode int
+	Error      string
+	CreatedAt  time.Time
+}
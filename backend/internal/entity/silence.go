@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Silence окно обслуживания: пока оно активно, недоступность подходящих контейнеров
+// не считается сбоем. Пустые селекторы не учитываются, Label задается в виде key=value
+type Silence struct {
+	ID        int64
+	Name      string
+	IP        string
+	Label     string
+	Network   string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Comment   string
+	CreatedAt time.Time
+}
+
+// IsActive проверяет, действует ли окно обслуживания в момент at
+func (s Silence) IsActive(at time.Time) bool {
+	return !at.Before(s.StartsAt) && at.Before(s.EndsAt)
+}
+
+// IsEmpty проверяет, что у окна обслуживания не задан ни один селектор
+func (s Silence) IsEmpty() bool {
+	return s.IP == "" && s.Name == "" && s.Label == "" && s.Network == ""
+}
+
+// Matches проверяет, попадает ли контейнер c под окно обслуживания: должны совпасть все заданные селекторы
+func (s Silence) Matches(c Container) bool {
+	if s.IsEmpty() {
+		return false
+	}
+
+	if s.IP != "" && s.IP != c.IP {
+		return false
+	}
+	if s.Name != "" && s.Name != c.Name {
+		return false
+	}
+	if s.Network != "" && s.Network != c.Network {
+		return false
+	}
+	if s.Label != "" {
+		key, value, _ := strings.Cut(s.Label, "=")
+		if v, ok := c.Labels[key]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// Типы записей хронологии контейнера
+const (
+	TimelineLifecycle = "lifecycle"
+	TimelineIncident  = "incident"
+)
+
+// Действия записей хронологии для инцидентов
+const (
+	IncidentStarted = "incident_started"
+	IncidentEnded   = "incident_ended"
+)
+
+// TimelineEntry запись хронологии контейнера: событие жизненного цикла или начало/конец инцидента
+type TimelineEntry struct {
+	Time      time.Time
+	Kind      string
+	Action    string
+	ExitCode  int
+	OOMKilled bool
+	Crashed   bool
+}
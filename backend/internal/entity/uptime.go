@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// Uptime показатели доступности контейнера за интервал времени. Up, Down и Unknown - количество
+// доступных, недоступных и непроверенных результатов, Availability равна nil, если известных результатов
+// в интервале нет. AvgRtt и MaxRtt считаются по результатам с ответами на ICMP-пинг
+type Uptime struct {
+	From          time.Time
+	To            time.Time
+	Checks        int
+	Up            int
+	Down          int
+	Unknown       int
+	Availability  *float64
+	Outages       int
+	MTTR          time.Duration
+	LongestOutage time.Duration
+	AvgRtt        float64
+	MaxRtt        float64
+}
@@ -0,0 +1 @@
+ALTER TABLE containers ADD COLUMN probes JSONB NOT NULL DEFAULT '[]';
\ No newline at end of file
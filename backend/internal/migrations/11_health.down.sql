@@ -0,0 +1,4 @@
+ALTER TABLE containers
+    DROP COLUMN IF EXISTS health_status,
+    DROP COLUMN IF EXISTS failing_streak,
+    DROP COLUMN IF EXISTS health_output;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE containers
+    ADD COLUMN health_status TEXT NOT NULL DEFAULT '',
+    ADD COLUMN failing_streak INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN health_output TEXT NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE container_events (
+    id BIGSERIAL PRIMARY KEY,
+    target_key TEXT NOT NULL,
+    container_id TEXT NOT NULL DEFAULT '',
+    container_name TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL,
+    exit_code INTEGER NOT NULL DEFAULT 0,
+    oom_killed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX container_events_target_key_idx ON container_events (target_key, created_at);
\ No newline at end of file
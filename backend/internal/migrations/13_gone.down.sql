@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS containers_gone_at_idx;
+
+ALTER TABLE containers
+    DROP COLUMN IF EXISTS gone_at;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE containers
+    ADD COLUMN gone_at TIMESTAMP WITHOUT TIME ZONE;
+
+CREATE INDEX containers_gone_at_idx ON containers (gone_at) WHERE gone_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE containers
+    DROP COLUMN IF EXISTS check_error;
\ No newline at end of file
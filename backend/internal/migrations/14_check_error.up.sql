@@ -0,0 +1,2 @@
+ALTER TABLE containers
+    ADD COLUMN check_error TEXT NOT NULL DEFAULT '';
\ No newline at end of file
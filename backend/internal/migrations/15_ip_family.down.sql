@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS containers_ip_family_idx;
+
+ALTER TABLE containers
+    DROP COLUMN IF EXISTS ip_family;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE containers
+    ADD COLUMN ip_family TEXT NOT NULL DEFAULT 'ipv4' CHECK (ip_family IN ('ipv4', 'ipv6'));
+
+UPDATE containers SET ip_family = 'ipv6' WHERE ip_address LIKE '%:%';
+
+CREATE INDEX containers_ip_family_idx ON containers (ip_family);
\ No newline at end of file
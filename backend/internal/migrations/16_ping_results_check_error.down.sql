@@ -0,0 +1,2 @@
+ALTER TABLE ping_results
+    DROP COLUMN IF EXISTS check_error;
\ No newline at end of file
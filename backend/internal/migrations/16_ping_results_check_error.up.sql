@@ -0,0 +1,2 @@
+ALTER TABLE ping_results
+    ADD COLUMN check_error TEXT NOT NULL DEFAULT '';
\ No newline at end of file
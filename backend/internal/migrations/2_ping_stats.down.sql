@@ -0,0 +1,9 @@
+ALTER TABLE containers
+    DROP COLUMN IF EXISTS packets_sent,
+    DROP COLUMN IF EXISTS packets_recv,
+    DROP COLUMN IF EXISTS packet_loss,
+    DROP COLUMN IF EXISTS min_rtt,
+    DROP COLUMN IF EXISTS avg_rtt,
+    DROP COLUMN IF EXISTS max_rtt,
+    DROP COLUMN IF EXISTS stddev_rtt,
+    DROP COLUMN IF EXISTS jitter;
\ No newline at end of file
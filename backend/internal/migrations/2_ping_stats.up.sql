@@ -0,0 +1,9 @@
+ALTER TABLE containers
+    ADD COLUMN packets_sent INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN packets_recv INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN packet_loss DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN min_rtt DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN avg_rtt DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN max_rtt DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN stddev_rtt DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN jitter DOUBLE PRECISION NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ping_results;
\ No newline at end of file
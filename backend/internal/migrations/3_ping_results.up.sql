@@ -0,0 +1,16 @@
+CREATE TABLE ping_results (
+    id BIGSERIAL PRIMARY KEY,
+    ip_address TEXT NOT NULL,
+    is_reachable BOOLEAN NOT NULL,
+    checked_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    packets_sent INTEGER NOT NULL DEFAULT 0,
+    packets_recv INTEGER NOT NULL DEFAULT 0,
+    packet_loss DOUBLE PRECISION NOT NULL DEFAULT 0,
+    min_rtt DOUBLE PRECISION NOT NULL DEFAULT 0,
+    avg_rtt DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_rtt DOUBLE PRECISION NOT NULL DEFAULT 0,
+    stddev_rtt DOUBLE PRECISION NOT NULL DEFAULT 0,
+    jitter DOUBLE PRECISION NOT NULL DEFAULT 0
+);
+
+CREATE INDEX ping_results_ip_checked_at_idx ON ping_results (ip_address, checked_at);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS incidents;
\ No newline at end of file
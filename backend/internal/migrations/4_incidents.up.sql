@@ -0,0 +1,9 @@
+CREATE TABLE incidents (
+    id BIGSERIAL PRIMARY KEY,
+    ip_address TEXT NOT NULL,
+    started_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE UNIQUE INDEX incidents_open_ip_idx ON incidents (ip_address) WHERE ended_at IS NULL;
+CREATE INDEX incidents_started_at_idx ON incidents (started_at);
\ No newline at end of file
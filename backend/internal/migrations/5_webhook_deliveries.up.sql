@@ -0,0 +1,14 @@
+CREATE TABLE webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    target TEXT NOT NULL,
+    url TEXT NOT NULL,
+    event TEXT NOT NULL,
+    ip_address TEXT NOT NULL,
+    success BOOLEAN NOT NULL,
+    attempts INTEGER NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX webhook_deliveries_target_idx ON webhook_deliveries (target, created_at);
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE alerts (
+    id BIGSERIAL PRIMARY KEY,
+    rule TEXT NOT NULL,
+    ip_address TEXT NOT NULL,
+    value DOUBLE PRECISION NOT NULL DEFAULT 0,
+    started_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    resolved_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE UNIQUE INDEX alerts_firing_idx ON alerts (rule, ip_address) WHERE resolved_at IS NULL;
+CREATE INDEX alerts_started_at_idx ON alerts (started_at);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE containers ADD COLUMN flapping BOOLEAN NOT NULL DEFAULT FALSE;
\ No newline at end of file
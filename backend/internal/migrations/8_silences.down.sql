@@ -0,0 +1,3 @@
+ALTER TABLE containers DROP COLUMN IF EXISTS in_maintenance;
+
+DROP TABLE IF EXISTS silences;
\ No newline at end of file
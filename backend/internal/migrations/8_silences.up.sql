@@ -0,0 +1,15 @@
+CREATE TABLE silences (
+    id BIGSERIAL PRIMARY KEY,
+    container_name TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    label TEXT NOT NULL DEFAULT '',
+    network TEXT NOT NULL DEFAULT '',
+    starts_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX silences_period_idx ON silences (starts_at, ends_at);
+
+ALTER TABLE containers ADD COLUMN in_maintenance BOOLEAN NOT NULL DEFAULT FALSE;
\ No newline at end of file
@@ -0,0 +1,26 @@
+DROP INDEX alerts_firing_idx;
+CREATE UNIQUE INDEX alerts_firing_idx ON alerts (rule, ip_address) WHERE resolved_at IS NULL;
+ALTER TABLE alerts DROP COLUMN target_key;
+
+DROP INDEX incidents_open_key_idx;
+CREATE UNIQUE INDEX incidents_open_ip_idx ON incidents (ip_address) WHERE ended_at IS NULL;
+ALTER TABLE incidents DROP COLUMN target_key;
+
+DROP INDEX ping_results_key_checked_at_idx;
+ALTER TABLE ping_results DROP COLUMN target_key;
+
+DELETE FROM containers WHERE target_key NOT IN (
+    SELECT DISTINCT ON (ip_address) target_key FROM containers ORDER BY ip_address, last_ping DESC
+);
+
+ALTER TABLE containers
+    DROP CONSTRAINT containers_pkey,
+    ADD PRIMARY KEY (ip_address),
+    DROP COLUMN target_key,
+    DROP COLUMN container_id,
+    DROP COLUMN container_name,
+    DROP COLUMN image,
+    DROP COLUMN compose_project,
+    DROP COLUMN compose_service,
+    DROP COLUMN network,
+    DROP COLUMN labels;
\ No newline at end of file
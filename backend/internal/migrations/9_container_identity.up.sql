@@ -0,0 +1,33 @@
+ALTER TABLE containers
+    ADD COLUMN target_key TEXT,
+    ADD COLUMN container_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN container_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN image TEXT NOT NULL DEFAULT '',
+    ADD COLUMN compose_project TEXT NOT NULL DEFAULT '',
+    ADD COLUMN compose_service TEXT NOT NULL DEFAULT '',
+    ADD COLUMN network TEXT NOT NULL DEFAULT '',
+    ADD COLUMN labels JSONB NOT NULL DEFAULT '{}';
+
+UPDATE containers SET target_key = ip_address;
+
+ALTER TABLE containers
+    DROP CONSTRAINT containers_pkey,
+    ALTER COLUMN target_key SET NOT NULL,
+    ADD PRIMARY KEY (target_key);
+
+ALTER TABLE ping_results ADD COLUMN target_key TEXT;
+UPDATE ping_results SET target_key = ip_address;
+ALTER TABLE ping_results ALTER COLUMN target_key SET NOT NULL;
+CREATE INDEX ping_results_key_checked_at_idx ON ping_results (target_key, checked_at);
+
+ALTER TABLE incidents ADD COLUMN target_key TEXT;
+UPDATE incidents SET target_key = ip_address;
+ALTER TABLE incidents ALTER COLUMN target_key SET NOT NULL;
+DROP INDEX incidents_open_ip_idx;
+CREATE UNIQUE INDEX incidents_open_key_idx ON incidents (target_key) WHERE ended_at IS NULL;
+
+ALTER TABLE alerts ADD COLUMN target_key TEXT;
+UPDATE alerts SET target_key = ip_address;
+ALTER TABLE alerts ALTER COLUMN target_key SET NOT NULL;
+DROP INDEX alerts_firing_idx;
+CREATE UNIQUE INDEX alerts_firing_idx ON alerts (rule, target_key) WHERE resolved_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,236 @@
+package notifier
+
+import (
+	"app-pinger/backend/internal/config"
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Pinger-Signature"
+	EventHeader     = "X-Pinger-Event"
+)
+
+// Payload тело запроса, которое получает webhook
+type Payload struct {
+	Event      string  `json:"event"`
+	Key        string  `json:"key,omitempty"`
+	Name       string  `json:"container_name,omitempty"`
+	IPAddress  string  `json:"ip_address"`
+	OldState   string  `json:"old_state,omitempty"`
+	NewState   string  `json:"new_state"`
+	Rule       string  `json:"rule,omitempty"`
+	Value      float64 `json:"value,omitempty"`
+	Timestamp  string  `json:"timestamp"`
+	PacketLoss float64 `json:"packet_loss"`
+	MinRtt     float64 `json:"min_rtt"`
+	AvgRtt     float64 `json:"avg_rtt"`
+	MaxRtt     float64 `json:"max_rtt"`
+	Jitter     float64 `json:"jitter"`
+}
+
+// WebhookNotifier отправляет уведомления POST-запросом на все webhook'и с повторами
+// и экспоненциальной задержкой, тело подписывается HMAC-SHA256 секретом получателя.
+// У каждого webhook'а своя ограниченная очередь, которую разбирает один обработчик из Run,
+// поэтому медленный получатель не задерживает остальных
+type WebhookNotifier struct {
+	webhooks   []config.Webhook
+	queues     []chan delivery
+	client     *http.Client
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	deliveries usecase.DeliveryRepo
+	log        *slog.Logger
+}
+
+// delivery уведомление, ожидающее отправки на webhook
+type delivery struct {
+	event string
+	ip    string
+	body  []byte
+}
+
+// check for implementation
+var _ usecase.Notifier = (*WebhookNotifier)(nil)
+
+func NewWebhookNotifier(cfg *config.NotifierConfig, d usecase.DeliveryRepo, l *slog.Logger) *WebhookNotifier {
+	queues := make([]chan delivery, len(cfg.Webhooks))
+	for i := range queues {
+		queues[i] = make(chan delivery, cfg.QueueSize)
+	}
+
+	return &WebhookNotifier{
+		webhooks:   cfg.Webhooks,
+		queues:     queues,
+		client:     &http.Client{Timeout: cfg.Timeout},
+		retries:    cfg.Retries,
+		backoff:    cfg.Backoff,
+		maxBackoff: cfg.MaxBackoff,
+		deliveries: d,
+		log:        l,
+	}
+}
+
+// Notify ставит уведомление n в очереди всех webhook'ов, не дожидаясь отправки.
+// Если очередь webhook'а заполнена, уведомление для него отбрасывается
+func (w *WebhookNotifier) Notify(_ context.Context, n entity.Notification) {
+	body, err := json.Marshal(newPayload(n))
+	if err != nil {
+		w.log.Error("failed to encode notification", slog.Any("error", err))
+		return
+	}
+
+	for i, webhook := range w.webhooks {
+		select {
+		case w.queues[i] <- delivery{event: n.Type, ip: n.IP, body: body}:
+		default:
+			w.log.Error("notification queue is full, notification dropped", slog.String("webhook", webhook.Name),
+				slog.String("event", n.Type), slog.String("IP", n.IP))
+		}
+	}
+}
+
+// Run разбирает очереди webhook'ов до отмены ctx и ждет завершения обработчиков. Отмена ctx прерывает
+// повторы текущих отправок, уведомления, оставшиеся в очередях, не отправляются
+func (w *WebhookNotifier) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for i, webhook := range w.webhooks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.work(ctx, webhook, w.queues[i])
+		}()
+	}
+
+	wg.Wait()
+}
+
+// work отправляет уведомления из очереди queue на webhook и сохраняет результаты доставки
+func (w *WebhookNotifier) work(ctx context.Context, webhook config.Webhook, queue <-chan delivery) {
+	for {
+		select {
+		case <-ctx.Done():
+			if len(queue) > 0 {
+				w.log.Warn("notifications dropped on shutdown", slog.String("webhook", webhook.Name),
+					slog.Int("count", len(queue)))
+			}
+			return
+		case d := <-queue:
+			result := w.deliver(ctx, webhook, d.event, d.body)
+			result.IP = d.ip
+
+			if !result.Success {
+				w.log.Error("failed to deliver notification", slog.String("webhook", webhook.Name),
+					slog.Int("attempts", result.Attempts), slog.String("error", result.Error))
+			}
+
+			// результат прерванной при остановке отправки тоже сохраняется
+			if _, err := w.deliveries.Add(context.WithoutCancel(ctx), result); err != nil {
+				w.log.Error("failed to save delivery", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// deliver отправляет body на webhook, повторяя запрос при ошибке не более retries раз
+func (w *WebhookNotifier) deliver(ctx context.Context, webhook config.Webhook, event string, body []byte) entity.Delivery {
+	delivery := entity.Delivery{
+		Target:    webhook.Name,
+		URL:       webhook.URL,
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	backoff := w.backoff
+	for attempt := 0; attempt <= w.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				delivery.Error = ctx.Err().Error()
+				return delivery
+			case <-time.After(backoff):
+			}
+
+			backoff = min(backoff*2, w.maxBackoff)
+		}
+
+		delivery.Attempts++
+
+		code, err := w.send(ctx, webhook, event, body)
+		delivery.StatusCode = code
+		if err == nil {
+			delivery.Success = true
+			delivery.Error = ""
+			return delivery
+		}
+
+		delivery.Error = err.Error()
+	}
+
+	return delivery
+}
+
+func (w *WebhookNotifier) send(ctx context.Context, webhook config.Webhook, event string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	if webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign возвращает подпись тела запроса в формате sha256=<hex>
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newPayload(n entity.Notification) Payload {
+	return Payload{
+		Event:      n.Type,
+		Key:        n.Key,
+		Name:       n.Name,
+		IPAddress:  n.IP,
+		OldState:   n.OldState,
+		NewState:   n.NewState,
+		Rule:       n.Rule,
+		Value:      n.Value,
+		Timestamp:  n.Timestamp.Format(time.DateTime),
+		PacketLoss: n.PacketLoss,
+		MinRtt:     n.MinRtt,
+		AvgRtt:     n.AvgRtt,
+		MaxRtt:     n.MaxRtt,
+		Jitter:     n.Jitter,
+	}
+}
@@ -0,0 +1,199 @@
+package notifier
+
+import (
+	"app-pinger/backend/internal/config"
+	"app-pinger/backend/internal/entity"
+	storagemock "app-pinger/backend/internal/usecase/repo/mock"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		retries      int
+		wantSuccess  bool
+		wantAttempts int
+		wantCode     int
+	}{
+		{
+			name:         "Delivered on first attempt",
+			failures:     0,
+			retries:      3,
+			wantSuccess:  true,
+			wantAttempts: 1,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:         "Delivered after retries",
+			failures:     2,
+			retries:      3,
+			wantSuccess:  true,
+			wantAttempts: 3,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:         "Retries exhausted",
+			failures:     10,
+			retries:      2,
+			wantSuccess:  false,
+			wantAttempts: 3,
+			wantCode:     http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			received := make(chan Payload, 1)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, Sign("secret", body), r.Header.Get(SignatureHeader))
+				require.Equal(t, entity.NotificationStateChanged, r.Header.Get(EventHeader))
+
+				if calls.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				var payload Payload
+				require.NoError(t, json.Unmarshal(body, &payload))
+				received <- payload
+			}))
+			defer srv.Close()
+
+			deliveries := storagemock.NewMockDeliveryRepo()
+			n := NewWebhookNotifier(&config.NotifierConfig{
+				Retries:    tt.retries,
+				Backoff:    time.Millisecond,
+				MaxBackoff: 4 * time.Millisecond,
+				Timeout:    time.Second,
+				QueueSize:  1,
+				Webhooks:   []config.Webhook{{Name: "oncall", URL: srv.URL, Secret: "secret"}},
+			}, deliveries, slog.Default())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go n.Run(ctx)
+
+			n.Notify(context.Background(), entity.Notification{
+				Type:      entity.NotificationStateChanged,
+				IP:        "192.168.0.1",
+				OldState:  entity.StateReachable,
+				NewState:  entity.StateUnreachable,
+				Timestamp: time.Date(2025, 2, 8, 10, 0, 0, 0, time.UTC),
+				PingStats: entity.PingStats{PacketLoss: 100},
+			})
+
+			var got []entity.Delivery
+			require.Eventually(t, func() bool {
+				got, _ = deliveries.GetAll(context.Background(), "oncall")
+				return len(got) == 1
+			}, time.Second, 5*time.Millisecond)
+
+			require.Equal(t, tt.wantSuccess, got[0].Success)
+			require.Equal(t, tt.wantAttempts, got[0].Attempts)
+			require.Equal(t, tt.wantCode, got[0].StatusCode)
+			require.Equal(t, "192.168.0.1", got[0].IP)
+
+			if tt.wantSuccess {
+				payload := <-received
+				require.Equal(t, Payload{
+					Event:      entity.NotificationStateChanged,
+					IPAddress:  "192.168.0.1",
+					OldState:   entity.StateReachable,
+					NewState:   entity.StateUnreachable,
+					Timestamp:  "2025-02-08 10:00:00",
+					PacketLoss: 100,
+				}, payload)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifier_QueueFull(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	deliveries := storagemock.NewMockDeliveryRepo()
+	n := NewWebhookNotifier(&config.NotifierConfig{
+		Backoff:    time.Millisecond,
+		MaxBackoff: time.Millisecond,
+		Timeout:    time.Second,
+		QueueSize:  2,
+		Webhooks:   []config.Webhook{{Name: "oncall", URL: srv.URL}},
+	}, deliveries, slog.Default())
+
+	// обработчик еще не запущен: уведомления сверх размера очереди отбрасываются, Notify не блокируется
+	for range 5 {
+		n.Notify(context.Background(), entity.Notification{Type: entity.NotificationStateChanged, IP: "192.168.0.1"})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go n.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		got, _ := deliveries.GetAll(context.Background(), "oncall")
+		return len(got) == 2
+	}, time.Second, 5*time.Millisecond)
+	require.Never(t, func() bool { return calls.Load() > 2 }, 50*time.Millisecond, 5*time.Millisecond)
+}
+
+func TestWebhookNotifier_RunStops(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	deliveries := storagemock.NewMockDeliveryRepo()
+	n := NewWebhookNotifier(&config.NotifierConfig{
+		Retries:    10,
+		Backoff:    time.Hour,
+		MaxBackoff: time.Hour,
+		Timeout:    time.Second,
+		QueueSize:  1,
+		Webhooks:   []config.Webhook{{Name: "oncall", URL: srv.URL}},
+	}, deliveries, slog.Default())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		n.Run(ctx)
+		close(done)
+	}()
+
+	n.Notify(context.Background(), entity.Notification{Type: entity.NotificationStateChanged, IP: "192.168.0.1"})
+
+	// первая попытка завершилась ошибкой, обработчик ждет повтора
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 5*time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after cancel")
+	}
+
+	got, err := deliveries.GetAll(context.Background(), "oncall")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.False(t, got[0].Success)
+	require.Equal(t, 1, got[0].Attempts)
+	require.Contains(t, got[0].Error, context.Canceled.Error())
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"app-pinger/backend/internal/entity"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// AlertFilter фильтр алертов, пустые поля не учитываются
+type AlertFilter struct {
+	Key   string
+	IP    string
+	Rule  string
+	State string
+}
+
+type AlertRepo interface {
+	Fire(ctx context.Context, a entity.Alert) (int64, error)
+	Resolve(ctx context.Context, id int64, resolvedAt time.Time) error
+	GetFiring(ctx context.Context, rule, key string) (*entity.Alert, error)
+	GetAll(ctx context.Context, filter AlertFilter) ([]entity.Alert, error)
+}
+
+// AlertEvaluator проверяет правила алертов по свежему результату пинга и истории контейнера
+type AlertEvaluator struct {
+	rules      []entity.AlertRule
+	containers ContainerRepo
+	alerts     AlertRepo
+	notifier   Notifier
+}
+
+func NewAlertEvaluator(rules []entity.AlertRule, c ContainerRepo, a AlertRepo, n Notifier) *AlertEvaluator {
+	return &AlertEvaluator{
+		rules:      rules,
+		containers: c,
+		alerts:     a,
+		notifier:   n,
+	}
+}
+
+// Evaluate проверяет все правила для контейнера c, открывая и закрывая алерты
+func (e *AlertEvaluator) Evaluate(ctx context.Context, c entity.Container) error {
+	const op = "AlertEvaluator - Evaluate"
+
+	for _, rule := range e.rules {
+		alert, err := e.alerts.GetFiring(ctx, rule.Name, c.Key)
+		if err != nil {
+			return fmt.Errorf("%s - e.alerts.GetFiring: %w", op, err)
+		}
+
+		results, err := e.results(ctx, rule, c)
+		if err != nil {
+			return fmt.Errorf("%s - e.results: %w", op, err)
+		}
+
+		firing, value := EvaluateRule(rule, results, alert != nil)
+
+		switch {
+		case firing && alert == nil:
+			_, err = e.alerts.Fire(ctx, entity.Alert{
+				Rule:      rule.Name,
+				Key:       c.Key,
+				IP:        c.IP,
+				Value:     value,
+				StartedAt: c.LastPing,
+			})
+			if err != nil {
+				return fmt.Errorf("%s - e.alerts.Fire: %w", op, err)
+			}
+			e.notify(ctx, entity.NotificationAlertFiring, rule, c, value)
+		case !firing && alert != nil:
+			if err = e.alerts.Resolve(ctx, alert.ID, c.LastPing); err != nil {
+				return fmt.Errorf("%s - e.alerts.Resolve: %w", op, err)
+			}
+			e.notify(ctx, entity.NotificationAlertResolved, rule, c, value)
+		}
+	}
+
+	return nil
+}
+
+// results возвращает историю контейнера, необходимую для проверки правила. Для правила недоступности
+// берутся последние известные результаты: неизвестные отбрасываются до ограничения количества
+func (e *AlertEvaluator) results(ctx context.Context, rule entity.AlertRule, c entity.Container) ([]entity.Container, error) {
+	if rule.Type == entity.RuleUnreachable {
+		return e.containers.Latest(ctx, c.Key, max(rule.Consecutive, rule.ResolveAfter))
+	}
+
+	return e.containers.History(ctx, c.Key, c.LastPing.Add(-rule.Window), c.LastPing)
+}
+
+func (e *AlertEvaluator) notify(ctx context.Context, event string, rule entity.AlertRule, c entity.Container, value float64) {
+	if e.notifier == nil {
+		return
+	}
+
+	state := entity.AlertFiring
+	if event == entity.NotificationAlertResolved {
+		state = entity.AlertResolved
+	}
+
+	e.notifier.Notify(ctx, entity.Notification{
+		Type:      event,
+		Key:       c.Key,
+		Name:      c.Name,
+		IP:        c.IP,
+		NewState:  state,
+		Rule:      rule.Name,
+		Value:     value,
+		Timestamp: c.LastPing,
+		PingStats: c.PingStats,
+	})
+}
+
+// EvaluateRule проверяет правило rule по результатам results, упорядоченным по времени.
+// firing - текущее состояние алерта, нужно для гистерезиса правила недоступности.
+// Результаты, которые pinger не смог получить (entity.Container.Unknown), не учитываются.
+// Возвращает новое состояние алерта и значение, по которому оно определено
+func EvaluateRule(rule entity.AlertRule, results []entity.Container, firing bool) (bool, float64) {
+	results = slices.DeleteFunc(slices.Clone(results), entity.Container.Unknown)
+
+	switch rule.Type {
+	case entity.RuleUnreachable:
+		unreachable := countLast(results, len(results), false)
+		if firing {
+			return countLast(results, rule.ResolveAfter, true) < rule.ResolveAfter, float64(unreachable)
+		}
+
+		return unreachable >= rule.Consecutive, float64(unreachable)
+	case entity.RulePacketLoss:
+		if len(results) == 0 {
+			return firing, 0
+		}
+
+		var loss float64
+		for _, r := range results {
+			loss += r.PacketLoss
+		}
+		loss /= float64(len(results))
+
+		return loss > rule.Threshold, loss
+	case entity.RuleLatency:
+		var rtt float64
+		var count int
+		for _, r := range results {
+			if r.PacketsRecv > 0 {
+				rtt += r.AvgRtt
+				count++
+			}
+		}
+		if count == 0 {
+			return firing, 0
+		}
+		rtt /= float64(count)
+
+		return rtt > rule.Threshold, rtt
+	}
+
+	return false, 0
+}
+
+// countLast считает, сколько последних результатов подряд (не более n) имеют доступность reachable
+func countLast(results []entity.Container, n int, reachable bool) int {
+	count := 0
+	for i := len(results) - 1; i >= 0 && count < n; i-- {
+		if results[i].IsReachable != reachable {
+			break
+		}
+		count++
+	}
+
+	return count
+}
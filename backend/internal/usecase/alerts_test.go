@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"app-pinger/backend/internal/entity"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestEvaluateRule(t *testing.T) {
+	down := entity.AlertRule{Name: "down", Type: entity.RuleUnreachable, Consecutive: 3, ResolveAfter: 2}
+	loss := entity.AlertRule{Name: "loss", Type: entity.RulePacketLoss, Threshold: 20, Window: 5 * time.Minute}
+	slow := entity.AlertRule{Name: "slow", Type: entity.RuleLatency, Threshold: 100, Window: 5 * time.Minute}
+
+	checks := func(reachable ...bool) []entity.Container {
+		results := make([]entity.Container, len(reachable))
+		for i, r := range reachable {
+			results[i] = entity.Container{IsReachable: r}
+		}
+		return results
+	}
+
+	tests := []struct {
+		name       string
+		rule       entity.AlertRule
+		results    []entity.Container
+		firing     bool
+		wantFiring bool
+		wantValue  float64
+	}{
+		{
+			name:       "Unreachable: not enough failures",
+			rule:       down,
+			results:    checks(true, false, false),
+			wantFiring: false,
+			wantValue:  2,
+		},
+		{
+			name:       "Unreachable: consecutive failures",
+			rule:       down,
+			results:    checks(false, false, false),
+			wantFiring: true,
+			wantValue:  3,
+		},
+		{
+			name:       "Unreachable: single success keeps firing",
+			rule:       down,
+			results:    checks(false, false, true),
+			firing:     true,
+			wantFiring: true,
+			wantValue:  0,
+		},
+		{
+			name:       "Unreachable: resolved after successes",
+			rule:       down,
+			results:    checks(false, true, true),
+			firing:     true,
+			wantFiring: false,
+			wantValue:  0,
+		},
+		{
+			name: "Packet loss: above threshold",
+			rule: loss,
+			results: []entity.Container{
+				{PingStats: entity.PingStats{PacketLoss: 0}},
+				{PingStats: entity.PingStats{PacketLoss: 50}},
+			},
+			wantFiring: true,
+			wantValue:  25,
+		},
+		{
+			name: "Packet loss: below threshold",
+			rule: loss,
+			results: []entity.Container{
+				{PingStats: entity.PingStats{PacketLoss: 0}},
+				{PingStats: entity.PingStats{PacketLoss: 25}},
+			},
+			firing:     true,
+			wantFiring: false,
+			wantValue:  12.5,
+		},
+		{
+			name:       "Packet loss: no data keeps state",
+			rule:       loss,
+			results:    nil,
+			firing:     true,
+			wantFiring: true,
+		},
+		{
+			name: "Unreachable: unknown results are skipped",
+			rule: down,
+			results: []entity.Container{
+				{}, {}, {CheckError: "socket: permission denied"}, {},
+			},
+			wantFiring: true,
+			wantValue:  3,
+		},
+		{
+			name: "Packet loss: unknown results are ignored",
+			rule: loss,
+			results: []entity.Container{
+				{IsReachable: true, PingStats: entity.PingStats{PacketLoss: 50}},
+				{CheckError: "socket: permission denied"},
+			},
+			wantFiring: true,
+			wantValue:  50,
+		},
+		{
+			name: "Latency: unreachable results are ignored",
+			rule: slow,
+			results: []entity.Container{
+				{IsReachable: true, PingStats: entity.PingStats{PacketsRecv: 4, AvgRtt: 150}},
+				{IsReachable: false},
+				{IsReachable: true, PingStats: entity.PingStats{PacketsRecv: 4, AvgRtt: 110}},
+			},
+			wantFiring: true,
+			wantValue:  130,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			firing, value := EvaluateRule(tt.rule, tt.results, tt.firing)
+			require.Equal(t, tt.wantFiring, firing)
+			require.Equal(t, tt.wantValue, value)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"app-pinger/backend/internal/entity"
+	"context"
+	"sort"
+)
+
+// EventRepo хранилище событий жизненного цикла контейнеров
+type EventRepo interface {
+	Add(ctx context.Context, e entity.ContainerEvent) (int64, error)
+	GetAll(ctx context.Context, key string, limit int) ([]entity.ContainerEvent, error)
+}
+
+// BuildTimeline объединяет события жизненного цикла events и инциденты incidents контейнера
+// в хронологию от новых записей к старым, не длиннее limit. По ней видно, был ли инцидент
+// вызван падением контейнера или проблемой сети
+func BuildTimeline(events []entity.ContainerEvent, incidents []entity.Incident, limit int) []entity.TimelineEntry {
+	timeline := make([]entity.TimelineEntry, 0, len(events)+2*len(incidents))
+
+	for _, e := range events {
+		timeline = append(timeline, entity.TimelineEntry{
+			Time:      e.Time,
+			Kind:      entity.TimelineLifecycle,
+			Action:    e.Action,
+			ExitCode:  e.ExitCode,
+			OOMKilled: e.OOMKilled,
+			Crashed:   e.Crashed(),
+		})
+	}
+
+	for _, i := range incidents {
+		timeline = append(timeline, entity.TimelineEntry{
+			Time:   i.StartedAt,
+			Kind:   entity.TimelineIncident,
+			Action: entity.IncidentStarted,
+		})
+
+		if !i.IsOpen() {
+			timeline = append(timeline, entity.TimelineEntry{
+				Time:   i.EndedAt,
+				Kind:   entity.TimelineIncident,
+				Action: entity.IncidentEnded,
+			})
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Time.After(timeline[j].Time)
+	})
+
+	if limit > 0 && len(timeline) > limit {
+		timeline = timeline[:limit]
+	}
+
+	return timeline
+}
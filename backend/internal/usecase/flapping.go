@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+)
+
+// FlappingDetector считает смены состояния контейнеров в скользящем окне window. Контейнер
+// считается нестабильным (flapping), пока число смен в окне не меньше threshold
+type FlappingDetector struct {
+	window    time.Duration
+	threshold int
+	changes   map[string][]time.Time
+	flapping  map[string]bool
+	mu        sync.Mutex
+}
+
+func NewFlappingDetector(window time.Duration, threshold int) *FlappingDetector {
+	return &FlappingDetector{
+		window:    window,
+		threshold: threshold,
+		changes:   map[string][]time.Time{},
+		flapping:  map[string]bool{},
+	}
+}
+
+// Record учитывает результат проверки контейнера key в момент at, changed - сменилось ли состояние.
+// Возвращает, нестабилен ли контейнер, и изменился ли этот признак
+func (f *FlappingDetector) Record(key string, at time.Time, changed bool) (bool, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	changes := f.changes[key]
+	if changed {
+		changes = append(changes, at)
+	}
+
+	start := at.Add(-f.window)
+	i := 0
+	for i < len(changes) && changes[i].Before(start) {
+		i++
+	}
+	changes = changes[i:]
+
+	if len(changes) == 0 {
+		delete(f.changes, key)
+	} else {
+		f.changes[key] = changes
+	}
+
+	flapping := len(changes) >= f.threshold
+	toggled := flapping != f.flapping[key]
+
+	if flapping {
+		f.flapping[key] = true
+	} else {
+		delete(f.flapping, key)
+	}
+
+	return flapping, toggled
+}
+
+// Forget удаляет историю смен состояния контейнера key
+func (f *FlappingDetector) Forget(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.changes, key)
+	delete(f.flapping, key)
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestFlappingDetector_Record(t *testing.T) {
+	start := time.Date(2025, 2, 8, 10, 0, 0, 0, time.UTC)
+
+	type record struct {
+		minute       int
+		changed      bool
+		wantFlapping bool
+		wantToggled  bool
+	}
+
+	tests := []struct {
+		name    string
+		records []record
+	}{
+		{
+			name: "Stable container",
+			records: []record{
+				{minute: 0, changed: true},
+				{minute: 1},
+				{minute: 2},
+			},
+		},
+		{
+			name: "Flapping starts and stops",
+			records: []record{
+				{minute: 0, changed: true},
+				{minute: 1, changed: true},
+				{minute: 2, changed: true, wantFlapping: true, wantToggled: true},
+				{minute: 3, changed: true, wantFlapping: true},
+				{minute: 6, wantFlapping: true},
+				{minute: 7, wantToggled: true},
+				{minute: 8},
+			},
+		},
+		{
+			name: "Changes outside window",
+			records: []record{
+				{minute: 0, changed: true},
+				{minute: 6, changed: true},
+				{minute: 12, changed: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFlappingDetector(5*time.Minute, 3)
+
+			for _, r := range tt.records {
+				flapping, toggled := f.Record("192.168.0.1", start.Add(time.Duration(r.minute)*time.Minute), r.changed)
+				require.Equal(t, r.wantFlapping, flapping, "minute %d", r.minute)
+				require.Equal(t, r.wantToggled, toggled, "minute %d", r.minute)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"app-pinger/backend/internal/entity"
+	"context"
+	"time"
+)
+
+// IncidentFilter фильтр инцидентов, пустые поля не учитываются
+type IncidentFilter struct {
+	Key   string
+	IP    string
+	State string
+}
+
+type IncidentRepo interface {
+	Open(ctx context.Context, key, IP string, startedAt time.Time) (int64, error)
+	Close(ctx context.Context, id int64, endedAt time.Time) error
+	GetOpen(ctx context.Context, key string) (*entity.Incident, error)
+	GetAll(ctx context.Context, filter IncidentFilter) ([]entity.Incident, error)
+}
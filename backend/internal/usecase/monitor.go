@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"app-pinger/backend/internal/entity"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Monitor обрабатывает результаты пингов: сохраняет их, отслеживает смену доступности контейнеров,
+// оповещает о ней через notifier и проверяет правила алертов alerts. Пока flapping считает контейнер
+// нестабильным, оповещения о каждой смене состояния не отправляются. Для контейнеров в окне
+// обслуживания из silences новые инциденты не открываются, а оповещения и алерты не вычисляются.
+// silences, notifier, alerts и flapping могут быть nil
+type Monitor struct {
+	containers ContainerRepo
+	incidents  IncidentRepo
+	silences   SilenceRepo
+	notifier   Notifier
+	alerts     *AlertEvaluator
+	flapping   *FlappingDetector
+}
+
+func NewMonitor(
+	c ContainerRepo,
+	i IncidentRepo,
+	s SilenceRepo,
+	n Notifier,
+	a *AlertEvaluator,
+	f *FlappingDetector,
+) *Monitor {
+	return &Monitor{
+		containers: c,
+		incidents:  i,
+		silences:   s,
+		notifier:   n,
+		alerts:     a,
+		flapping:   f,
+	}
+}
+
+// Process сохраняет результат пинга c, открывает инцидент при переходе контейнера в недоступное
+// состояние и закрывает его при восстановлении. Результаты без ключа контейнера (от старых версий
+// пингера) идентифицируются по IP-адресу. Если pinger не смог проверить контейнер, его состояние
+// неизвестно: инцидент не открывается, оповещения и алерты не вычисляются
+func (m *Monitor) Process(ctx context.Context, c entity.Container) error {
+	const op = "Monitor - Process"
+
+	if c.Key == "" {
+		c.Key = c.IP
+	}
+
+	incident, err := m.incidents.GetOpen(ctx, c.Key)
+	if err != nil {
+		return fmt.Errorf("%s - m.incidents.GetOpen: %w", op, err)
+	}
+
+	c.InMaintenance, err = m.inMaintenance(ctx, c)
+	if err != nil {
+		return fmt.Errorf("%s - m.inMaintenance: %w", op, err)
+	}
+
+	opened := !c.IsReachable && incident == nil && !c.InMaintenance && !c.Unknown()
+	closed := c.IsReachable && incident != nil
+
+	toggled := false
+	if m.flapping != nil {
+		c.Flapping, toggled = m.flapping.Record(c.Key, c.LastPing, opened || closed)
+	}
+
+	key, err := m.containers.Add(ctx, c)
+	if err != nil {
+		return fmt.Errorf("%s - m.containers.Add: %w", op, err)
+	}
+	if key != c.Key {
+		return fmt.Errorf("%s: unexpected container %s, want %s", op, key, c.Key)
+	}
+
+	switch {
+	case opened:
+		if _, err = m.incidents.Open(ctx, c.Key, c.IP, c.LastPing); err != nil {
+			return fmt.Errorf("%s - m.incidents.Open: %w", op, err)
+		}
+	case closed:
+		if err = m.incidents.Close(ctx, incident.ID, c.LastPing); err != nil {
+			return fmt.Errorf("%s - m.incidents.Close: %w", op, err)
+		}
+	}
+
+	if c.InMaintenance || c.Unknown() {
+		return nil
+	}
+
+	switch {
+	case toggled && c.Flapping:
+		m.notify(ctx, entity.NotificationFlappingStarted, c, "", entity.StateFlapping)
+	case toggled:
+		m.notify(ctx, entity.NotificationFlappingStopped, c, entity.StateFlapping, state(c.IsReachable))
+	case c.Flapping:
+		// пока контейнер нестабилен, о смене состояния не оповещаем
+	case opened:
+		m.notify(ctx, entity.NotificationStateChanged, c, entity.StateReachable, entity.StateUnreachable)
+	case closed:
+		m.notify(ctx, entity.NotificationStateChanged, c, entity.StateUnreachable, entity.StateReachable)
+	}
+
+	if m.alerts != nil {
+		if err = m.alerts.Evaluate(ctx, c); err != nil {
+			return fmt.Errorf("%s - m.alerts.Evaluate: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// Stop сохраняет недоступность контейнера key, который упал или был остановлен в момент at:
+// pinger его больше не проверяет, поэтому без этого контейнер остался бы доступным с устаревшим
+// last_ping. Открытие инцидента и оповещения выполняются как для обычного результата пинга.
+// Более новый результат и удаленный контейнер не меняются
+func (m *Monitor) Stop(ctx context.Context, key string, at time.Time) error {
+	const op = "Monitor - Stop"
+
+	c, err := m.containers.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%s - m.containers.Get: %w", op, err)
+	}
+	if c == nil || c.Gone() || !c.LastPing.Before(at) {
+		return nil
+	}
+
+	c.IsReachable = false
+	c.LastPing = at
+	c.PingStats = entity.PingStats{}
+	c.Probes = nil
+	c.CheckError = ""
+
+	if err = m.Process(ctx, *c); err != nil {
+		return fmt.Errorf("%s - m.Process: %w", op, err)
+	}
+
+	return nil
+}
+
+// Remove помечает контейнер key удаленным на момент at и закрывает его открытый инцидент:
+// pinger больше не проверяет контейнер, и восстановления доступности не будет
+func (m *Monitor) Remove(ctx context.Context, key string, at time.Time) error {
+	const op = "Monitor - Remove"
+
+	if err := m.containers.MarkGone(ctx, key, at); err != nil {
+		return fmt.Errorf("%s - m.containers.MarkGone: %w", op, err)
+	}
+
+	incident, err := m.incidents.GetOpen(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%s - m.incidents.GetOpen: %w", op, err)
+	}
+
+	if incident != nil {
+		if err = m.incidents.Close(ctx, incident.ID, at); err != nil {
+			return fmt.Errorf("%s - m.incidents.Close: %w", op, err)
+		}
+	}
+
+	if m.flapping != nil {
+		m.flapping.Forget(key)
+	}
+
+	return nil
+}
+
+// Maintenance пересчитывает признак обслуживания контейнеров containers по окнам, активным в момент at.
+// Сохраненный признак устаревает, когда окно удаляют или оно истекает, а новых пингов еще не было
+func (m *Monitor) Maintenance(ctx context.Context, containers []entity.Container, at time.Time) error {
+	const op = "Monitor - Maintenance"
+
+	if m.silences == nil {
+		return nil
+	}
+
+	silences, err := m.silences.GetActive(ctx, at)
+	if err != nil {
+		return fmt.Errorf("%s - m.silences.GetActive: %w", op, err)
+	}
+
+	for i := range containers {
+		containers[i].InMaintenance = matchesAny(silences, containers[i])
+	}
+
+	return nil
+}
+
+// inMaintenance проверяет, попадает ли контейнер c под активное окно обслуживания
+func (m *Monitor) inMaintenance(ctx context.Context, c entity.Container) (bool, error) {
+	if m.silences == nil {
+		return false, nil
+	}
+
+	silences, err := m.silences.GetActive(ctx, c.LastPing)
+	if err != nil {
+		return false, err
+	}
+
+	return matchesAny(silences, c), nil
+}
+
+func matchesAny(silences []entity.Silence, c entity.Container) bool {
+	for _, s := range silences {
+		if s.Matches(c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Monitor) notify(ctx context.Context, event string, c entity.Container, oldState, newState string) {
+	if m.notifier == nil {
+		return
+	}
+
+	m.notifier.Notify(ctx, entity.Notification{
+		Type:      event,
+		Key:       c.Key,
+		Name:      c.Name,
+		IP:        c.IP,
+		OldState:  oldState,
+		NewState:  newState,
+		Timestamp: c.LastPing,
+		PingStats: c.PingStats,
+	})
+}
+
+func state(reachable bool) string {
+	if reachable {
+		return entity.StateReachable
+	}
+
+	return entity.StateUnreachable
+}
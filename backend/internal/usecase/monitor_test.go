@@ -0,0 +1,162 @@
+package usecase_test
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	storagemock "app-pinger/backend/internal/usecase/repo/mock"
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type recordNotifier struct {
+	notifications []entity.Notification
+}
+
+func (r *recordNotifier) Notify(ctx context.Context, n entity.Notification) {
+	r.notifications = append(r.notifications, n)
+}
+
+func TestMonitor_Process(t *testing.T) {
+	start := time.Date(2025, 2, 8, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		reachable []bool
+		errors    []string
+		want      []entity.Incident
+		states    []string
+	}{
+		{
+			name:      "Always reachable",
+			reachable: []bool{true, true, true},
+			want:      []entity.Incident{},
+		},
+		{
+			name:      "Goes down",
+			reachable: []bool{true, false, false},
+			want: []entity.Incident{
+				{ID: 1, Key: "192.168.0.1", IP: "192.168.0.1", StartedAt: start.Add(time.Minute)},
+			},
+			states: []string{entity.StateUnreachable},
+		},
+		{
+			name:      "Goes down and up twice",
+			reachable: []bool{false, true, true, false, false, true},
+			want: []entity.Incident{
+				{ID: 1, Key: "192.168.0.1", IP: "192.168.0.1", StartedAt: start, EndedAt: start.Add(time.Minute)},
+				{ID: 2, Key: "192.168.0.1", IP: "192.168.0.1", StartedAt: start.Add(3 * time.Minute), EndedAt: start.Add(5 * time.Minute)},
+			},
+			states: []string{entity.StateUnreachable, entity.StateReachable, entity.StateUnreachable, entity.StateReachable},
+		},
+		{
+			name:      "Ping could not run",
+			reachable: []bool{true, false, false},
+			errors:    []string{"", "socket: permission denied", "socket: permission denied"},
+			want:      []entity.Incident{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incidents := storagemock.NewMockIncidentRepo()
+			notifier := &recordNotifier{}
+			monitor := usecase.NewMonitor(storagemock.NewMockRepo(entity.Container{}), incidents, nil, notifier, nil, nil)
+
+			for i, reachable := range tt.reachable {
+				c := entity.Container{
+					IP:          "192.168.0.1",
+					IsReachable: reachable,
+					LastPing:    start.Add(time.Duration(i) * time.Minute),
+				}
+				if tt.errors != nil {
+					c.CheckError = tt.errors[i]
+				}
+
+				err := monitor.Process(context.Background(), c)
+				require.NoError(t, err)
+			}
+
+			got, err := incidents.GetAll(context.Background(), usecase.IncidentFilter{})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			var states []string
+			for _, n := range notifier.notifications {
+				require.NotEqual(t, n.OldState, n.NewState)
+				states = append(states, n.NewState)
+			}
+			require.Equal(t, tt.states, states)
+		})
+	}
+}
+
+func TestMonitor_ProcessFlapping(t *testing.T) {
+	start := time.Date(2025, 2, 8, 10, 0, 0, 0, time.UTC)
+
+	notifier := &recordNotifier{}
+	monitor := usecase.NewMonitor(storagemock.NewMockRepo(entity.Container{}), storagemock.NewMockIncidentRepo(),
+		nil, notifier, nil, usecase.NewFlappingDetector(10*time.Minute, 3))
+
+	reachable := []bool{false, true, false, true, true, true, true, true, true, true, true, true, true}
+	for i, r := range reachable {
+		err := monitor.Process(context.Background(), entity.Container{
+			IP:          "192.168.0.1",
+			IsReachable: r,
+			LastPing:    start.Add(time.Duration(i) * time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	var events []string
+	for _, n := range notifier.notifications {
+		events = append(events, n.Type)
+	}
+
+	require.Equal(t, []string{
+		entity.NotificationStateChanged,
+		entity.NotificationStateChanged,
+		entity.NotificationFlappingStarted,
+		entity.NotificationFlappingStopped,
+	}, events)
+	require.Equal(t, start.Add(12*time.Minute), notifier.notifications[3].Timestamp)
+}
+
+func TestMonitor_ProcessMaintenance(t *testing.T) {
+	start := time.Date(2025, 2, 8, 10, 0, 0, 0, time.UTC)
+
+	incidents := storagemock.NewMockIncidentRepo()
+	notifier := &recordNotifier{}
+	silences := storagemock.NewMockSilenceRepo(entity.Silence{
+		ID:       1,
+		Label:    "com.docker.compose.service=web",
+		StartsAt: start,
+		EndsAt:   start.Add(5 * time.Minute),
+	})
+	monitor := usecase.NewMonitor(storagemock.NewMockRepo(entity.Container{}), incidents, silences, notifier, nil, nil)
+
+	// недоступен во время обслуживания, восстановлен, затем упал после окончания окна
+	reachable := []bool{false, false, true, true, true, false}
+	for i, r := range reachable {
+		err := monitor.Process(context.Background(), entity.Container{
+			IP:          "192.168.0.1",
+			IsReachable: r,
+			LastPing:    start.Add(time.Duration(i) * time.Minute),
+			ContainerInfo: entity.ContainerInfo{
+				Key:     "app.web.1@app_default",
+				Network: "app_default",
+				Labels:  map[string]string{"com.docker.compose.service": "web"},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := incidents.GetAll(context.Background(), usecase.IncidentFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []entity.Incident{
+		{ID: 1, Key: "app.web.1@app_default", IP: "192.168.0.1", StartedAt: start.Add(5 * time.Minute)},
+	}, got)
+
+	require.Len(t, notifier.notifications, 1)
+	require.Equal(t, entity.StateUnreachable, notifier.notifications[0].NewState)
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"app-pinger/backend/internal/entity"
+	"context"
+)
+
+// Notifier отправляет уведомления о событиях, отправка не должна блокировать вызывающего
+type Notifier interface {
+	Notify(ctx context.Context, n entity.Notification)
+}
+
+type DeliveryRepo interface {
+	Add(ctx context.Context, d entity.Delivery) (int64, error)
+	GetAll(ctx context.Context, target string) ([]entity.Delivery, error)
+}
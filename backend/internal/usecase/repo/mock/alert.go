@@ -0,0 +1,81 @@
+package storagemock
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"context"
+	"sync"
+	"time"
+)
+
+type MockAlertRepo struct {
+	alerts []entity.Alert
+	mu     sync.Mutex
+}
+
+// check for implementation
+var _ usecase.AlertRepo = (*MockAlertRepo)(nil)
+
+func NewMockAlertRepo(alerts ...entity.Alert) *MockAlertRepo {
+	return &MockAlertRepo{alerts: alerts}
+}
+
+func (m *MockAlertRepo) Fire(ctx context.Context, a entity.Alert) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a.ID = int64(len(m.alerts) + 1)
+	m.alerts = append(m.alerts, a)
+
+	return a.ID, nil
+}
+
+func (m *MockAlertRepo) Resolve(ctx context.Context, id int64, resolvedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.alerts {
+		if m.alerts[i].ID == id && m.alerts[i].State() == entity.AlertFiring {
+			m.alerts[i].ResolvedAt = resolvedAt
+		}
+	}
+
+	return nil
+}
+
+func (m *MockAlertRepo) GetFiring(ctx context.Context, rule, key string) (*entity.Alert, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, a := range m.alerts {
+		if a.Rule == rule && a.Key == key && a.State() == entity.AlertFiring {
+			return &a, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *MockAlertRepo) GetAll(ctx context.Context, filter usecase.AlertFilter) ([]entity.Alert, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	alerts := []entity.Alert{}
+	for _, a := range m.alerts {
+		if filter.Key != "" && a.Key != filter.Key {
+			continue
+		}
+		if filter.IP != "" && a.IP != filter.IP {
+			continue
+		}
+		if filter.Rule != "" && a.Rule != filter.Rule {
+			continue
+		}
+		if filter.State != "" && a.State() != filter.State {
+			continue
+		}
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,44 @@
+package storagemock
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"context"
+	"sync"
+)
+
+type MockDeliveryRepo struct {
+	deliveries []entity.Delivery
+	mu         sync.Mutex
+}
+
+// check for implementation
+var _ usecase.DeliveryRepo = (*MockDeliveryRepo)(nil)
+
+func NewMockDeliveryRepo(deliveries ...entity.Delivery) *MockDeliveryRepo {
+	return &MockDeliveryRepo{deliveries: deliveries}
+}
+
+func (m *MockDeliveryRepo) Add(ctx context.Context, d entity.Delivery) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d.ID = int64(len(m.deliveries) + 1)
+	m.deliveries = append(m.deliveries, d)
+
+	return d.ID, nil
+}
+
+func (m *MockDeliveryRepo) GetAll(ctx context.Context, target string) ([]entity.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deliveries := []entity.Delivery{}
+	for _, d := range m.deliveries {
+		if target == "" || d.Target == target {
+			deliveries = append(deliveries, d)
+		}
+	}
+
+	return deliveries, nil
+}
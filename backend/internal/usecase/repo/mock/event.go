@@ -0,0 +1,44 @@
+package storagemock
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"context"
+	"sync"
+)
+
+type MockEventRepo struct {
+	events []entity.ContainerEvent
+	mu     sync.Mutex
+}
+
+// check for implementation
+var _ usecase.EventRepo = (*MockEventRepo)(nil)
+
+func NewMockEventRepo(events ...entity.ContainerEvent) *MockEventRepo {
+	return &MockEventRepo{events: events}
+}
+
+func (m *MockEventRepo) Add(ctx context.Context, e entity.ContainerEvent) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e.ID = int64(len(m.events) + 1)
+	m.events = append(m.events, e)
+
+	return e.ID, nil
+}
+
+func (m *MockEventRepo) GetAll(ctx context.Context, key string, limit int) ([]entity.ContainerEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	events := []entity.ContainerEvent{}
+	for _, e := range m.events {
+		if e.Key == key && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,78 @@
+package storagemock
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"context"
+	"sync"
+	"time"
+)
+
+type MockIncidentRepo struct {
+	incidents []entity.Incident
+	mu        sync.Mutex
+}
+
+// check for implementation
+var _ usecase.IncidentRepo = (*MockIncidentRepo)(nil)
+
+func NewMockIncidentRepo(incidents ...entity.Incident) *MockIncidentRepo {
+	return &MockIncidentRepo{incidents: incidents}
+}
+
+func (m *MockIncidentRepo) Open(ctx context.Context, key, IP string, startedAt time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := int64(len(m.incidents) + 1)
+	m.incidents = append(m.incidents, entity.Incident{ID: id, Key: key, IP: IP, StartedAt: startedAt})
+
+	return id, nil
+}
+
+func (m *MockIncidentRepo) Close(ctx context.Context, id int64, endedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.incidents {
+		if m.incidents[i].ID == id && m.incidents[i].IsOpen() {
+			m.incidents[i].EndedAt = endedAt
+		}
+	}
+
+	return nil
+}
+
+func (m *MockIncidentRepo) GetOpen(ctx context.Context, key string) (*entity.Incident, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, incident := range m.incidents {
+		if incident.Key == key && incident.IsOpen() {
+			return &incident, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *MockIncidentRepo) GetAll(ctx context.Context, filter usecase.IncidentFilter) ([]entity.Incident, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	incidents := []entity.Incident{}
+	for _, incident := range m.incidents {
+		if filter.Key != "" && incident.Key != filter.Key {
+			continue
+		}
+		if filter.IP != "" && incident.IP != filter.IP {
+			continue
+		}
+		if filter.State != "" && incident.State() != filter.State {
+			continue
+		}
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, nil
+}
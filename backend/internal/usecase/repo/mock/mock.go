@@ -4,6 +4,7 @@ import (
 	"app-pinger/backend/internal/entity"
 	"app-pinger/backend/internal/usecase"
 	"context"
+	"time"
 )
 
 type MockRepo struct {
@@ -18,9 +19,69 @@ func NewMockRepo(c entity.Container) *MockRepo {
 }
 
 func (m *MockRepo) Add(ctx context.Context, container entity.Container) (string, error) {
-	return container.IP, nil
+	if m.container.Key == container.Key && m.container.LastPing.Before(container.LastPing) {
+		m.container = container
+	}
+
+	return container.Key, nil
+}
+
+func (m MockRepo) GetAll(ctx context.Context, filter usecase.ContainerFilter) ([]entity.Container, error) {
+	if filter.Family != "" && m.container.Family != filter.Family {
+		return []entity.Container{}, nil
+	}
+
+	return []entity.Container{m.container}, nil
+}
+
+func (m MockRepo) Get(ctx context.Context, key string) (*entity.Container, error) {
+	if m.container.Key != key {
+		return nil, nil
+	}
+
+	container := m.container
+	return &container, nil
 }
 
-func (m MockRepo) GetAll(ctx context.Context) ([]entity.Container, error) {
+func (m MockRepo) History(ctx context.Context, key string, from, to time.Time) ([]entity.Container, error) {
+	if m.container.Key != key {
+		return []entity.Container{}, nil
+	}
+
 	return []entity.Container{m.container}, nil
 }
+
+func (m MockRepo) Latest(ctx context.Context, key string, limit int) ([]entity.Container, error) {
+	if m.container.Key != key || limit < 1 || m.container.Unknown() {
+		return []entity.Container{}, nil
+	}
+
+	return []entity.Container{m.container}, nil
+}
+
+func (m MockRepo) Uptime(ctx context.Context, key string, from, to time.Time) (entity.Uptime, error) {
+	results, err := m.History(ctx, key, from, to)
+	if err != nil {
+		return entity.Uptime{}, err
+	}
+
+	return usecase.CalculateUptime(results, from, to), nil
+}
+
+func (m *MockRepo) MarkGone(ctx context.Context, key string, at time.Time) error {
+	if m.container.Key == key {
+		m.container.GoneAt = at
+	}
+
+	return nil
+}
+
+func (m *MockRepo) DeleteGone(ctx context.Context, before time.Time) (int64, error) {
+	if !m.container.Gone() || !m.container.GoneAt.Before(before) {
+		return 0, nil
+	}
+
+	m.container = entity.Container{}
+
+	return 1, nil
+}
@@ -0,0 +1,73 @@
+package storagemock
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"context"
+	"sync"
+	"time"
+)
+
+type MockSilenceRepo struct {
+	silences []entity.Silence
+	lastID   int64
+	mu       sync.Mutex
+}
+
+// check for implementation
+var _ usecase.SilenceRepo = (*MockSilenceRepo)(nil)
+
+func NewMockSilenceRepo(silences ...entity.Silence) *MockSilenceRepo {
+	m := &MockSilenceRepo{silences: silences}
+	for _, s := range silences {
+		m.lastID = max(m.lastID, s.ID)
+	}
+
+	return m
+}
+
+func (m *MockSilenceRepo) Add(ctx context.Context, s entity.Silence) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastID++
+	s.ID = m.lastID
+	m.silences = append(m.silences, s)
+
+	return s.ID, nil
+}
+
+func (m *MockSilenceRepo) Delete(ctx context.Context, id int64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, s := range m.silences {
+		if s.ID == id {
+			m.silences = append(m.silences[:i], m.silences[i+1:]...)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *MockSilenceRepo) GetAll(ctx context.Context) ([]entity.Silence, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]entity.Silence{}, m.silences...), nil
+}
+
+func (m *MockSilenceRepo) GetActive(ctx context.Context, at time.Time) ([]entity.Silence, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	silences := []entity.Silence{}
+	for _, s := range m.silences {
+		if s.IsActive(at) {
+			silences = append(silences, s)
+		}
+	}
+
+	return silences, nil
+}
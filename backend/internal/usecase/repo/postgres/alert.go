@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type AlertRepo struct {
+	*sql.DB
+}
+
+// check for implementation
+var _ usecase.AlertRepo = (*AlertRepo)(nil)
+
+func NewAlertRepo(db *sql.DB) *AlertRepo {
+	return &AlertRepo{db}
+}
+
+func (a *AlertRepo) Fire(ctx context.Context, alert entity.Alert) (int64, error) {
+	const op = "AlertRepo - Fire"
+
+	query := "INSERT INTO alerts(rule, target_key, ip_address, value, started_at) " +
+		"VALUES($1, $2, $3, $4, $5) RETURNING id"
+
+	var id int64
+
+	err := a.QueryRowContext(ctx, query, alert.Rule, alert.Key, alert.IP, alert.Value, alert.StartedAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s - a.QueryRowContext: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (a *AlertRepo) Resolve(ctx context.Context, id int64, resolvedAt time.Time) error {
+	const op = "AlertRepo - Resolve"
+
+	query := "UPDATE alerts SET resolved_at = $2 WHERE id = $1 AND resolved_at IS NULL"
+
+	_, err := a.ExecContext(ctx, query, id, resolvedAt)
+	if err != nil {
+		return fmt.Errorf("%s - a.ExecContext: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *AlertRepo) GetFiring(ctx context.Context, rule, key string) (*entity.Alert, error) {
+	const op = "AlertRepo - GetFiring"
+
+	query := "SELECT id, rule, target_key, ip_address, value, started_at FROM alerts " +
+		"WHERE rule = $1 AND target_key = $2 AND resolved_at IS NULL"
+
+	var alert entity.Alert
+
+	err := a.QueryRowContext(ctx, query, rule, key).Scan(&alert.ID, &alert.Rule, &alert.Key, &alert.IP,
+		&alert.Value, &alert.StartedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s - a.QueryRowContext: %w", op, err)
+	}
+
+	return &alert, nil
+}
+
+func (a *AlertRepo) GetAll(ctx context.Context, filter usecase.AlertFilter) ([]entity.Alert, error) {
+	const op = "AlertRepo - GetAll"
+
+	query := "SELECT id, rule, target_key, ip_address, value, started_at, resolved_at FROM alerts WHERE TRUE"
+	var args []interface{}
+
+	if filter.Key != "" {
+		args = append(args, filter.Key)
+		query += fmt.Sprintf(" AND target_key = $%d", len(args))
+	}
+
+	if filter.IP != "" {
+		args = append(args, filter.IP)
+		query += fmt.Sprintf(" AND ip_address = $%d", len(args))
+	}
+
+	if filter.Rule != "" {
+		args = append(args, filter.Rule)
+		query += fmt.Sprintf(" AND rule = $%d", len(args))
+	}
+
+	switch filter.State {
+	case entity.AlertFiring:
+		query += " AND resolved_at IS NULL"
+	case entity.AlertResolved:
+		query += " AND resolved_at IS NOT NULL"
+	}
+
+	query += " ORDER BY started_at DESC"
+
+	rows, err := a.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s - a.QueryContext: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	alerts := []entity.Alert{}
+
+	for rows.Next() {
+		var alert entity.Alert
+		var resolvedAt sql.NullTime
+
+		err = rows.Scan(&alert.ID, &alert.Rule, &alert.Key, &alert.IP, &alert.Value, &alert.StartedAt, &resolvedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s - rows.Scan: %w", op, err)
+		}
+		alert.ResolvedAt = resolvedAt.Time
+
+		alerts = append(alerts, alert)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s - rows.Err: %w", op, err)
+	}
+
+	return alerts, nil
+}
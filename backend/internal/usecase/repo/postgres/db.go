@@ -5,10 +5,18 @@ import (
 	"app-pinger/backend/internal/usecase"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 )
 
+// containerColumns столбцы containers, которые читает scanContainer
+const containerColumns = "target_key, ip_address, is_reachable, last_ping, packets_sent, packets_recv, packet_loss, " +
+	"min_rtt, avg_rtt, max_rtt, stddev_rtt, jitter, flapping, in_maintenance, container_id, " +
+	"container_name, image, compose_project, compose_service, network, labels, probes, health_status, " +
+	"failing_streak, health_output, gone_at, check_error, ip_family"
+
 type ContainerRepo struct {
 	*sql.DB
 }
@@ -23,35 +31,102 @@ func NewContainerRepo(db *sql.DB) *ContainerRepo {
 func (c *ContainerRepo) Add(ctx context.Context, container entity.Container) (string, error) {
 	const op = "ContainerRepo - Add"
 
-	query := "INSERT INTO containers(ip_address, is_reachable, last_ping) " +
-		"VALUES($1, $2, $3) " +
-		"ON CONFLICT(ip_address) " +
+	tx, err := c.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("%s - c.BeginTx: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	labels, err := json.Marshal(container.Labels)
+	if err != nil {
+		return "", fmt.Errorf("%s - json.Marshal: %w", op, err)
+	}
+
+	probes, err := json.Marshal(container.Probes)
+	if err != nil {
+		return "", fmt.Errorf("%s - json.Marshal: %w", op, err)
+	}
+
+	query := "INSERT INTO containers(target_key, ip_address, is_reachable, last_ping, packets_sent, packets_recv, " +
+		"packet_loss, min_rtt, avg_rtt, max_rtt, stddev_rtt, jitter, flapping, in_maintenance, " +
+		"container_id, container_name, image, compose_project, compose_service, network, labels, probes, " +
+		"health_status, failing_streak, health_output, check_error, ip_family) " +
+		"VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, " +
+		"$22, $23, $24, $25, $26, $27) " +
+		"ON CONFLICT(target_key) " +
 		"DO UPDATE SET " +
+		"ip_address = EXCLUDED.ip_address, " +
+		"ip_family = EXCLUDED.ip_family, " +
 		"is_reachable = EXCLUDED.is_reachable, " +
-		"last_ping = EXCLUDED.last_ping " +
+		"last_ping = EXCLUDED.last_ping, " +
+		"packets_sent = EXCLUDED.packets_sent, " +
+		"packets_recv = EXCLUDED.packets_recv, " +
+		"packet_loss = EXCLUDED.packet_loss, " +
+		"min_rtt = EXCLUDED.min_rtt, " +
+		"avg_rtt = EXCLUDED.avg_rtt, " +
+		"max_rtt = EXCLUDED.max_rtt, " +
+		"stddev_rtt = EXCLUDED.stddev_rtt, " +
+		"jitter = EXCLUDED.jitter, " +
+		"flapping = EXCLUDED.flapping, " +
+		"in_maintenance = EXCLUDED.in_maintenance, " +
+		"container_id = EXCLUDED.container_id, " +
+		"container_name = EXCLUDED.container_name, " +
+		"image = EXCLUDED.image, " +
+		"compose_project = EXCLUDED.compose_project, " +
+		"compose_service = EXCLUDED.compose_service, " +
+		"network = EXCLUDED.network, " +
+		"labels = EXCLUDED.labels, " +
+		"probes = EXCLUDED.probes, " +
+		"health_status = EXCLUDED.health_status, " +
+		"failing_streak = EXCLUDED.failing_streak, " +
+		"health_output = EXCLUDED.health_output, " +
+		"check_error = EXCLUDED.check_error, " +
+		"gone_at = CASE WHEN containers.gone_at < EXCLUDED.last_ping THEN NULL ELSE containers.gone_at END " +
 		"WHERE containers.last_ping < EXCLUDED.last_ping " +
-		"RETURNING ip_address"
+		"RETURNING target_key"
 
-	var containerID string
+	key := container.Key
 
-	err := c.QueryRowContext(ctx, query, container.IP, container.IsReachable, container.LastPing).Scan(&containerID)
-	if errors.Is(err, sql.ErrNoRows) {
-		return container.IP, nil
+	err = tx.QueryRowContext(ctx, query, container.Key, container.IP, container.IsReachable, container.LastPing,
+		container.PacketsSent, container.PacketsRecv, container.PacketLoss, container.MinRtt,
+		container.AvgRtt, container.MaxRtt, container.StdDevRtt, container.Jitter,
+		container.Flapping, container.InMaintenance, container.ContainerID, container.Name,
+		container.Image, container.Project, container.Service, container.Network, labels, probes, container.Health.Status,
+		container.Health.FailingStreak, container.Health.Output, container.CheckError, container.Family).Scan(&key)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%s - tx.QueryRowContext: %w", op, err)
 	}
+
+	query = "INSERT INTO ping_results(target_key, ip_address, is_reachable, checked_at, packets_sent, packets_recv, " +
+		"packet_loss, min_rtt, avg_rtt, max_rtt, stddev_rtt, jitter, check_error) " +
+		"VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)"
+
+	_, err = tx.ExecContext(ctx, query, container.Key, container.IP, container.IsReachable, container.LastPing,
+		container.PacketsSent, container.PacketsRecv, container.PacketLoss, container.MinRtt,
+		container.AvgRtt, container.MaxRtt, container.StdDevRtt, container.Jitter, container.CheckError)
 	if err != nil {
-		return "", fmt.Errorf("%s - c.QueryRowContext: %w", op, err)
+		return "", fmt.Errorf("%s - tx.ExecContext: %w", op, err)
 	}
 
-	return containerID, nil
+	if err = tx.Commit(); err != nil {
+		return "", fmt.Errorf("%s - tx.Commit: %w", op, err)
+	}
 
+	return key, nil
 }
 
-func (c ContainerRepo) GetAll(ctx context.Context) ([]entity.Container, error) {
+func (c ContainerRepo) GetAll(ctx context.Context, filter usecase.ContainerFilter) ([]entity.Container, error) {
 	const op = "ContainerRepo - GetAll"
 
-	query := "SELECT ip_address, is_reachable, last_ping FROM containers"
+	query := "SELECT " + containerColumns + " FROM containers WHERE TRUE"
+	var args []interface{}
 
-	rows, err := c.QueryContext(ctx, query)
+	if filter.Family != "" {
+		args = append(args, filter.Family)
+		query += fmt.Sprintf(" AND ip_family = $%d", len(args))
+	}
+
+	rows, err := c.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s - c.QueryContext: %w", op, err)
 	}
@@ -61,12 +136,253 @@ func (c ContainerRepo) GetAll(ctx context.Context) ([]entity.Container, error) {
 	containers := []entity.Container{}
 
 	for rows.Next() {
-		var container entity.Container
-
-		rows.Scan(&container.IP, &container.IsReachable, &container.LastPing)
+		container, err := scanContainer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s - scanContainer: %w", op, err)
+		}
 
 		containers = append(containers, container)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s - rows.Err: %w", op, err)
+	}
+
 	return containers, nil
 }
+
+// Get возвращает последний результат контейнера key или nil, если контейнер не найден
+func (c ContainerRepo) Get(ctx context.Context, key string) (*entity.Container, error) {
+	const op = "ContainerRepo - Get"
+
+	query := "SELECT " + containerColumns + " FROM containers WHERE target_key = $1"
+
+	container, err := scanContainer(c.QueryRowContext(ctx, query, key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s - scanContainer: %w", op, err)
+	}
+
+	return &container, nil
+}
+
+// scanContainer читает строку containers со столбцами containerColumns
+func scanContainer(row interface{ Scan(dest ...any) error }) (entity.Container, error) {
+	var container entity.Container
+	var labels, probes []byte
+	var goneAt sql.NullTime
+
+	err := row.Scan(&container.Key, &container.IP, &container.IsReachable, &container.LastPing, &container.PacketsSent,
+		&container.PacketsRecv, &container.PacketLoss, &container.MinRtt, &container.AvgRtt,
+		&container.MaxRtt, &container.StdDevRtt, &container.Jitter, &container.Flapping,
+		&container.InMaintenance, &container.ContainerID, &container.Name, &container.Image,
+		&container.Project, &container.Service, &container.Network, &labels, &probes,
+		&container.Health.Status, &container.Health.FailingStreak, &container.Health.Output, &goneAt,
+		&container.CheckError, &container.Family)
+	if err != nil {
+		return container, err
+	}
+
+	if err = json.Unmarshal(labels, &container.Labels); err != nil {
+		return container, err
+	}
+	if err = json.Unmarshal(probes, &container.Probes); err != nil {
+		return container, err
+	}
+	container.GoneAt = goneAt.Time
+
+	return container, nil
+}
+
+func (c ContainerRepo) History(ctx context.Context, key string, from, to time.Time) ([]entity.Container, error) {
+	const op = "ContainerRepo - History"
+
+	query := "SELECT target_key, ip_address, is_reachable, checked_at, packets_sent, packets_recv, packet_loss, " +
+		"min_rtt, avg_rtt, max_rtt, stddev_rtt, jitter, check_error FROM ping_results " +
+		"WHERE target_key = $1 AND checked_at >= $2 AND checked_at <= $3 " +
+		"ORDER BY checked_at"
+
+	rows, err := c.QueryContext(ctx, query, key, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s - c.QueryContext: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	results, err := scanResults(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s - scanResults: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// uptimeQuery считает показатели доступности контейнера $1 за интервал $2-$3 так же, как usecase.CalculateUptime:
+// checks - результаты с длительностью до следующего результата (последний действует до конца интервала),
+// changes - смены доступности среди известных результатов, outages - сбои с временем восстановления
+const uptimeQuery = `
+WITH checks AS (
+	SELECT checked_at, is_reachable, NOT is_reachable AND check_error <> '' AS unknown,
+		packets_recv, avg_rtt, max_rtt,
+		EXTRACT(EPOCH FROM LEAD(checked_at, 1, $3::timestamp) OVER (ORDER BY checked_at) - checked_at)::float8 AS period
+	FROM ping_results
+	WHERE target_key = $1 AND checked_at >= $2 AND checked_at <= $3
+), changes AS (
+	SELECT checked_at, is_reachable,
+		LAG(is_reachable) OVER (ORDER BY checked_at) IS DISTINCT FROM is_reachable AS changed
+	FROM checks
+	WHERE NOT unknown
+), outages AS (
+	SELECT checked_at AS started, is_reachable, LEAD(checked_at) OVER (ORDER BY checked_at) AS resolved
+	FROM changes
+	WHERE changed
+), totals AS (
+	SELECT COUNT(*) AS checks,
+		COUNT(*) FILTER (WHERE is_reachable) AS up,
+		COUNT(*) FILTER (WHERE NOT is_reachable AND NOT unknown) AS down,
+		COUNT(*) FILTER (WHERE unknown) AS unknown,
+		COALESCE(SUM(period) FILTER (WHERE is_reachable), 0) AS up_time,
+		COALESCE(SUM(period) FILTER (WHERE NOT is_reachable AND NOT unknown), 0) AS down_time,
+		(ARRAY_AGG(is_reachable ORDER BY checked_at DESC) FILTER (WHERE NOT unknown))[1] AS last_reachable,
+		COALESCE(AVG(avg_rtt) FILTER (WHERE packets_recv > 0), 0) AS avg_rtt,
+		COALESCE(MAX(max_rtt) FILTER (WHERE packets_recv > 0), 0) AS max_rtt
+	FROM checks
+)
+SELECT t.checks, t.up, t.down, t.unknown,
+	CASE
+		WHEN t.up_time + t.down_time > 0 THEN t.up_time / (t.up_time + t.down_time) * 100
+		WHEN t.last_reachable THEN 100
+		WHEN NOT t.last_reachable THEN 0
+	END,
+	o.outages, o.mttr, o.longest, t.avg_rtt, t.max_rtt
+FROM totals t, (
+	SELECT COUNT(*) AS outages,
+		COALESCE(AVG(EXTRACT(EPOCH FROM resolved - started)), 0)::float8 AS mttr,
+		COALESCE(MAX(EXTRACT(EPOCH FROM COALESCE(resolved, $3::timestamp) - started)), 0)::float8 AS longest
+	FROM outages
+	WHERE NOT is_reachable
+) o`
+
+// Uptime считает показатели доступности контейнера key за интервал from-to запросом к ping_results
+func (c ContainerRepo) Uptime(ctx context.Context, key string, from, to time.Time) (entity.Uptime, error) {
+	const op = "ContainerRepo - Uptime"
+
+	uptime := entity.Uptime{From: from, To: to}
+
+	var availability sql.NullFloat64
+	var mttr, longest float64
+
+	err := c.QueryRowContext(ctx, uptimeQuery, key, from, to).Scan(&uptime.Checks, &uptime.Up, &uptime.Down,
+		&uptime.Unknown, &availability, &uptime.Outages, &mttr, &longest, &uptime.AvgRtt, &uptime.MaxRtt)
+	if err != nil {
+		return uptime, fmt.Errorf("%s - c.QueryRowContext: %w", op, err)
+	}
+
+	if availability.Valid {
+		uptime.Availability = &availability.Float64
+	}
+	uptime.MTTR = time.Duration(mttr * float64(time.Second))
+	uptime.LongestOutage = time.Duration(longest * float64(time.Second))
+
+	return uptime, nil
+}
+
+// Latest возвращает последние limit известных результатов пингов контейнера key в порядке возрастания
+// времени. Результаты, которые pinger не смог получить (check_error без ответа), пропускаются до LIMIT,
+// иначе серия неизвестных проверок вытесняла бы из выборки результаты, по которым проверяются алерты
+func (c ContainerRepo) Latest(ctx context.Context, key string, limit int) ([]entity.Container, error) {
+	const op = "ContainerRepo - Latest"
+
+	query := "SELECT * FROM (SELECT target_key, ip_address, is_reachable, checked_at, packets_sent, packets_recv, " +
+		"packet_loss, min_rtt, avg_rtt, max_rtt, stddev_rtt, jitter, check_error FROM ping_results " +
+		"WHERE target_key = $1 AND (is_reachable OR check_error = '') " +
+		"ORDER BY checked_at DESC LIMIT $2) latest " +
+		"ORDER BY checked_at"
+
+	rows, err := c.QueryContext(ctx, query, key, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s - c.QueryContext: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	results, err := scanResults(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s - scanResults: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// MarkGone помечает контейнер key удаленным на момент at. Результат пинга новее at снимает отметку
+func (c ContainerRepo) MarkGone(ctx context.Context, key string, at time.Time) error {
+	const op = "ContainerRepo - MarkGone"
+
+	query := "UPDATE containers SET gone_at = $2 WHERE target_key = $1 AND last_ping <= $2"
+
+	if _, err := c.ExecContext(ctx, query, key, at); err != nil {
+		return fmt.Errorf("%s - c.ExecContext: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteGone удаляет контейнеры, помеченные удаленными раньше before, вместе с историей их пингов.
+// Возвращает количество удаленных контейнеров
+func (c ContainerRepo) DeleteGone(ctx context.Context, before time.Time) (int64, error) {
+	const op = "ContainerRepo - DeleteGone"
+
+	tx, err := c.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s - c.BeginTx: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	query := "DELETE FROM ping_results WHERE target_key IN " +
+		"(SELECT target_key FROM containers WHERE gone_at < $1)"
+
+	if _, err = tx.ExecContext(ctx, query, before); err != nil {
+		return 0, fmt.Errorf("%s - tx.ExecContext: %w", op, err)
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM containers WHERE gone_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("%s - tx.ExecContext: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s - res.RowsAffected: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s - tx.Commit: %w", op, err)
+	}
+
+	return deleted, nil
+}
+
+func scanResults(rows *sql.Rows) ([]entity.Container, error) {
+	results := []entity.Container{}
+
+	for rows.Next() {
+		var result entity.Container
+
+		err := rows.Scan(&result.Key, &result.IP, &result.IsReachable, &result.LastPing, &result.PacketsSent,
+			&result.PacketsRecv, &result.PacketLoss, &result.MinRtt, &result.AvgRtt,
+			&result.MaxRtt, &result.StdDevRtt, &result.Jitter, &result.CheckError)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DeliveryRepo struct {
+	*sql.DB
+}
+
+// check for implementation
+var _ usecase.DeliveryRepo = (*DeliveryRepo)(nil)
+
+func NewDeliveryRepo(db *sql.DB) *DeliveryRepo {
+	return &DeliveryRepo{db}
+}
+
+func (d *DeliveryRepo) Add(ctx context.Context, delivery entity.Delivery) (int64, error) {
+	const op = "DeliveryRepo - Add"
+
+	query := "INSERT INTO webhook_deliveries(target, url, event, ip_address, success, attempts, " +
+		"status_code, error, created_at) " +
+		"VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id"
+
+	var id int64
+
+	err := d.QueryRowContext(ctx, query, delivery.Target, delivery.URL, delivery.Event, delivery.IP,
+		delivery.Success, delivery.Attempts, delivery.StatusCode, delivery.Error, delivery.CreatedAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s - d.QueryRowContext: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (d *DeliveryRepo) GetAll(ctx context.Context, target string) ([]entity.Delivery, error) {
+	const op = "DeliveryRepo - GetAll"
+
+	query := "SELECT id, target, url, event, ip_address, success, attempts, status_code, error, created_at " +
+		"FROM webhook_deliveries WHERE $1 = '' OR target = $1 ORDER BY created_at DESC LIMIT 1000"
+
+	rows, err := d.QueryContext(ctx, query, target)
+	if err != nil {
+		return nil, fmt.Errorf("%s - d.QueryContext: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	deliveries := []entity.Delivery{}
+
+	for rows.Next() {
+		var delivery entity.Delivery
+
+		err = rows.Scan(&delivery.ID, &delivery.Target, &delivery.URL, &delivery.Event, &delivery.IP,
+			&delivery.Success, &delivery.Attempts, &delivery.StatusCode, &delivery.Error, &delivery.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s - rows.Scan: %w", op, err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s - rows.Err: %w", op, err)
+	}
+
+	return deliveries, nil
+}
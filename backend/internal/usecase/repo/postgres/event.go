@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type EventRepo struct {
+	*sql.DB
+}
+
+// check for implementation
+var _ usecase.EventRepo = (*EventRepo)(nil)
+
+func NewEventRepo(db *sql.DB) *EventRepo {
+	return &EventRepo{db}
+}
+
+func (e *EventRepo) Add(ctx context.Context, event entity.ContainerEvent) (int64, error) {
+	const op = "EventRepo - Add"
+
+	query := "INSERT INTO container_events(target_key, container_id, container_name, action, exit_code, " +
+		"oom_killed, created_at) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id"
+
+	var id int64
+
+	err := e.QueryRowContext(ctx, query, event.Key, event.ContainerID, event.Name, event.Action, event.ExitCode,
+		event.OOMKilled, event.Time).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s - e.QueryRowContext: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (e *EventRepo) GetAll(ctx context.Context, key string, limit int) ([]entity.ContainerEvent, error) {
+	const op = "EventRepo - GetAll"
+
+	query := "SELECT id, target_key, container_id, container_name, action, exit_code, oom_killed, created_at " +
+		"FROM container_events WHERE target_key = $1 ORDER BY created_at DESC LIMIT $2"
+
+	rows, err := e.QueryContext(ctx, query, key, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s - e.QueryContext: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	events := []entity.ContainerEvent{}
+
+	for rows.Next() {
+		var event entity.ContainerEvent
+
+		err = rows.Scan(&event.ID, &event.Key, &event.ContainerID, &event.Name, &event.Action, &event.ExitCode,
+			&event.OOMKilled, &event.Time)
+		if err != nil {
+			return nil, fmt.Errorf("%s - rows.Scan: %w", op, err)
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s - rows.Err: %w", op, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type IncidentRepo struct {
+	*sql.DB
+}
+
+// check for implementation
+var _ usecase.IncidentRepo = (*IncidentRepo)(nil)
+
+func NewIncidentRepo(db *sql.DB) *IncidentRepo {
+	return &IncidentRepo{db}
+}
+
+func (i *IncidentRepo) Open(ctx context.Context, key, IP string, startedAt time.Time) (int64, error) {
+	const op = "IncidentRepo - Open"
+
+	query := "INSERT INTO incidents(target_key, ip_address, started_at) VALUES($1, $2, $3) RETURNING id"
+
+	var id int64
+
+	err := i.QueryRowContext(ctx, query, key, IP, startedAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s - i.QueryRowContext: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (i *IncidentRepo) Close(ctx context.Context, id int64, endedAt time.Time) error {
+	const op = "IncidentRepo - Close"
+
+	query := "UPDATE incidents SET ended_at = $2 WHERE id = $1 AND ended_at IS NULL"
+
+	_, err := i.ExecContext(ctx, query, id, endedAt)
+	if err != nil {
+		return fmt.Errorf("%s - i.ExecContext: %w", op, err)
+	}
+
+	return nil
+}
+
+func (i *IncidentRepo) GetOpen(ctx context.Context, key string) (*entity.Incident, error) {
+	const op = "IncidentRepo - GetOpen"
+
+	query := "SELECT id, target_key, ip_address, started_at FROM incidents WHERE target_key = $1 AND ended_at IS NULL"
+
+	var incident entity.Incident
+
+	err := i.QueryRowContext(ctx, query, key).Scan(&incident.ID, &incident.Key, &incident.IP, &incident.StartedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s - i.QueryRowContext: %w", op, err)
+	}
+
+	return &incident, nil
+}
+
+func (i *IncidentRepo) GetAll(ctx context.Context, filter usecase.IncidentFilter) ([]entity.Incident, error) {
+	const op = "IncidentRepo - GetAll"
+
+	query := "SELECT id, target_key, ip_address, started_at, ended_at FROM incidents WHERE TRUE"
+	var args []interface{}
+
+	if filter.Key != "" {
+		args = append(args, filter.Key)
+		query += fmt.Sprintf(" AND target_key = $%d", len(args))
+	}
+
+	if filter.IP != "" {
+		args = append(args, filter.IP)
+		query += fmt.Sprintf(" AND ip_address = $%d", len(args))
+	}
+
+	switch filter.State {
+	case entity.IncidentOpen:
+		query += " AND ended_at IS NULL"
+	case entity.IncidentClosed:
+		query += " AND ended_at IS NOT NULL"
+	}
+
+	query += " ORDER BY started_at DESC"
+
+	rows, err := i.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s - i.QueryContext: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	incidents := []entity.Incident{}
+
+	for rows.Next() {
+		var incident entity.Incident
+		var endedAt sql.NullTime
+
+		err = rows.Scan(&incident.ID, &incident.Key, &incident.IP, &incident.StartedAt, &endedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s - rows.Scan: %w", op, err)
+		}
+		incident.EndedAt = endedAt.Time
+
+		incidents = append(incidents, incident)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s - rows.Err: %w", op, err)
+	}
+
+	return incidents, nil
+}
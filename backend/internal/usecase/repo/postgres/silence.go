@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"app-pinger/backend/internal/entity"
+	"app-pinger/backend/internal/usecase"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type SilenceRepo struct {
+	*sql.DB
+}
+
+// check for implementation
+var _ usecase.SilenceRepo = (*SilenceRepo)(nil)
+
+func NewSilenceRepo(db *sql.DB) *SilenceRepo {
+	return &SilenceRepo{db}
+}
+
+func (s *SilenceRepo) Add(ctx context.Context, silence entity.Silence) (int64, error) {
+	const op = "SilenceRepo - Add"
+
+	query := "INSERT INTO silences(container_name, ip_address, label, network, starts_at, ends_at, comment, created_at) " +
+		"VALUES($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id"
+
+	var id int64
+
+	err := s.QueryRowContext(ctx, query, silence.Name, silence.IP, silence.Label, silence.Network,
+		silence.StartsAt, silence.EndsAt, silence.Comment, silence.CreatedAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s - s.QueryRowContext: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *SilenceRepo) Delete(ctx context.Context, id int64) (bool, error) {
+	const op = "SilenceRepo - Delete"
+
+	query := "DELETE FROM silences WHERE id = $1"
+
+	res, err := s.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("%s - s.ExecContext: %w", op, err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s - res.RowsAffected: %w", op, err)
+	}
+
+	return count > 0, nil
+}
+
+func (s *SilenceRepo) GetAll(ctx context.Context) ([]entity.Silence, error) {
+	const op = "SilenceRepo - GetAll"
+
+	query := "SELECT id, container_name, ip_address, label, network, starts_at, ends_at, comment, created_at " +
+		"FROM silences ORDER BY starts_at DESC"
+
+	rows, err := s.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s - s.QueryContext: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	silences, err := scanSilences(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s - scanSilences: %w", op, err)
+	}
+
+	return silences, nil
+}
+
+func (s *SilenceRepo) GetActive(ctx context.Context, at time.Time) ([]entity.Silence, error) {
+	const op = "SilenceRepo - GetActive"
+
+	query := "SELECT id, container_name, ip_address, label, network, starts_at, ends_at, comment, created_at " +
+		"FROM silences WHERE starts_at <= $1 AND ends_at > $1"
+
+	rows, err := s.QueryContext(ctx, query, at)
+	if err != nil {
+		return nil, fmt.Errorf("%s - s.QueryContext: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	silences, err := scanSilences(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s - scanSilences: %w", op, err)
+	}
+
+	return silences, nil
+}
+
+func scanSilences(rows *sql.Rows) ([]entity.Silence, error) {
+	silences := []entity.Silence{}
+
+	for rows.Next() {
+		var silence entity.Silence
+
+		err := rows.Scan(&silence.ID, &silence.Name, &silence.IP, &silence.Label, &silence.Network,
+			&silence.StartsAt, &silence.EndsAt, &silence.Comment, &silence.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		silences = append(silences, silence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return silences, nil
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"app-pinger/backend/internal/entity"
+	"context"
+	"time"
+)
+
+type SilenceRepo interface {
+	Add(ctx context.Context, s entity.Silence) (int64, error)
+	Delete(ctx context.Context, id int64) (bool, error)
+	GetAll(ctx context.Context) ([]entity.Silence, error)
+	GetActive(ctx context.Context, at time.Time) ([]entity.Silence, error)
+}
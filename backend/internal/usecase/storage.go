@@ -4,11 +4,24 @@ import (
 	"app-pinger/backend/internal/entity"
 	"context"
 	"fmt"
+	"time"
 )
 
+// ContainerFilter фильтр контейнеров, пустые поля не учитываются
+type ContainerFilter struct {
+	Family string
+}
+
+// ContainerRepo хранилище результатов пингов, контейнеры идентифицируются ключом entity.ContainerInfo.Key
 type ContainerRepo interface {
 	Add(ctx context.Context, c entity.Container) (string, error)
-	GetAll(ctx context.Context) ([]entity.Container, error)
+	GetAll(ctx context.Context, filter ContainerFilter) ([]entity.Container, error)
+	Get(ctx context.Context, key string) (*entity.Container, error)
+	History(ctx context.Context, key string, from, to time.Time) ([]entity.Container, error)
+	Latest(ctx context.Context, key string, limit int) ([]entity.Container, error)
+	Uptime(ctx context.Context, key string, from, to time.Time) (entity.Uptime, error)
+	MarkGone(ctx context.Context, key string, at time.Time) error
+	DeleteGone(ctx context.Context, before time.Time) (int64, error)
 }
 
 type BackendService struct {
@@ -22,21 +35,86 @@ func NewBackendService(repo ContainerRepo) *BackendService {
 func (b *BackendService) Add(ctx context.Context, c entity.Container) (string, error) {
 	const op = "BackendService - Add"
 
-	IP, err := b.repo.Add(ctx, c)
+	key, err := b.repo.Add(ctx, c)
 	if err != nil {
 		return "", fmt.Errorf("%s - b.repo.Add: %w", op, err)
 	}
 
-	return IP, nil
+	return key, nil
 }
 
-func (b *BackendService) GetAll(ctx context.Context) ([]entity.Container, error) {
+func (b *BackendService) GetAll(ctx context.Context, filter ContainerFilter) ([]entity.Container, error) {
 	const op = "BackendService - GetAll"
 
-	containers, err := b.repo.GetAll(ctx)
+	containers, err := b.repo.GetAll(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("%s - b.repo.GetAll: %w", op, err)
 	}
 
 	return containers, nil
 }
+
+func (b *BackendService) Get(ctx context.Context, key string) (*entity.Container, error) {
+	const op = "BackendService - Get"
+
+	container, err := b.repo.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("%s - b.repo.Get: %w", op, err)
+	}
+
+	return container, nil
+}
+
+func (b *BackendService) History(ctx context.Context, key string, from, to time.Time) ([]entity.Container, error) {
+	const op = "BackendService - History"
+
+	results, err := b.repo.History(ctx, key, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s - b.repo.History: %w", op, err)
+	}
+
+	return results, nil
+}
+
+func (b *BackendService) Uptime(ctx context.Context, key string, from, to time.Time) (entity.Uptime, error) {
+	const op = "BackendService - Uptime"
+
+	uptime, err := b.repo.Uptime(ctx, key, from, to)
+	if err != nil {
+		return uptime, fmt.Errorf("%s - b.repo.Uptime: %w", op, err)
+	}
+
+	return uptime, nil
+}
+
+func (b *BackendService) Latest(ctx context.Context, key string, limit int) ([]entity.Container, error) {
+	const op = "BackendService - Latest"
+
+	results, err := b.repo.Latest(ctx, key, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s - b.repo.Latest: %w", op, err)
+	}
+
+	return results, nil
+}
+
+func (b *BackendService) MarkGone(ctx context.Context, key string, at time.Time) error {
+	const op = "BackendService - MarkGone"
+
+	if err := b.repo.MarkGone(ctx, key, at); err != nil {
+		return fmt.Errorf("%s - b.repo.MarkGone: %w", op, err)
+	}
+
+	return nil
+}
+
+func (b *BackendService) DeleteGone(ctx context.Context, before time.Time) (int64, error) {
+	const op = "BackendService - DeleteGone"
+
+	deleted, err := b.repo.DeleteGone(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("%s - b.repo.DeleteGone: %w", op, err)
+	}
+
+	return deleted, nil
+}
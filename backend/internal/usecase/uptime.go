@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"app-pinger/backend/internal/entity"
+	"sort"
+	"time"
+)
+
+// CalculateUptime считает доступность контейнера по результатам пингов results за интервал from-to.
+// Состояние контейнера считается неизменным от одного результата до следующего, последний результат
+// действует до конца интервала, время до первого результата в расчет не входит.
+// Availability - процент времени доступности, MTTR - среднее время восстановления по завершенным сбоям.
+// Время после результатов, которые pinger не смог получить (entity.Container.Unknown), не считается
+// ни доступностью, ни сбоем и не прерывает текущий сбой. Postgres-хранилище считает те же показатели
+// запросом (ContainerRepo.Uptime), не загружая историю, CalculateUptime используется хранилищами в памяти
+func CalculateUptime(results []entity.Container, from, to time.Time) entity.Uptime {
+	uptime := entity.Uptime{From: from, To: to}
+
+	checks := make([]entity.Container, 0, len(results))
+	for _, r := range results {
+		if !r.LastPing.Before(from) && !r.LastPing.After(to) {
+			checks = append(checks, r)
+		}
+	}
+
+	uptime.Checks = len(checks)
+	if len(checks) == 0 {
+		return uptime
+	}
+
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].LastPing.Before(checks[j].LastPing)
+	})
+
+	var up, down, repaired time.Duration
+	var resolved, replies int
+	var rtt float64
+	var outageStart time.Time
+	var last *entity.Container
+	inOutage := false
+
+	for i, check := range checks {
+		end := to
+		if i+1 < len(checks) {
+			end = checks[i+1].LastPing
+		}
+		period := end.Sub(check.LastPing)
+
+		if check.PacketsRecv > 0 {
+			replies++
+			rtt += check.AvgRtt
+			uptime.MaxRtt = max(uptime.MaxRtt, check.MaxRtt)
+		}
+
+		if check.Unknown() {
+			uptime.Unknown++
+			continue
+		}
+		last = &checks[i]
+
+		if check.IsReachable {
+			uptime.Up++
+			up += period
+			if inOutage {
+				outage := check.LastPing.Sub(outageStart)
+				repaired += outage
+				resolved++
+				uptime.LongestOutage = max(uptime.LongestOutage, outage)
+				inOutage = false
+			}
+			continue
+		}
+
+		uptime.Down++
+		down += period
+		if !inOutage {
+			outageStart = check.LastPing
+			uptime.Outages++
+			inOutage = true
+		}
+	}
+
+	if inOutage {
+		uptime.LongestOutage = max(uptime.LongestOutage, to.Sub(outageStart))
+	}
+
+	if resolved > 0 {
+		uptime.MTTR = repaired / time.Duration(resolved)
+	}
+	if replies > 0 {
+		uptime.AvgRtt = rtt / float64(replies)
+	}
+
+	if last != nil {
+		uptime.Availability = availability(up, down, last.IsReachable)
+	}
+
+	return uptime
+}
+
+// availability возвращает процент времени доступности up от известного времени up+down. Если известное
+// время нулевое (все известные результаты на конце интервала), доступность определяет последний из них
+func availability(up, down time.Duration, lastReachable bool) *float64 {
+	var percent float64
+	if total := up + down; total > 0 {
+		percent = float64(up) / float64(total) * 100
+	} else if lastReachable {
+		percent = 100
+	}
+
+	return &percent
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"app-pinger/backend/internal/entity"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCalculateUptime(t *testing.T) {
+	from := time.Date(2025, 2, 8, 10, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Minute)
+
+	check := func(minute int, reachable bool) entity.Container {
+		return entity.Container{
+			IP:          "192.168.0.1",
+			IsReachable: reachable,
+			LastPing:    from.Add(time.Duration(minute) * time.Minute),
+		}
+	}
+
+	unknown := func(minute int) entity.Container {
+		return entity.Container{
+			IP:         "192.168.0.1",
+			LastPing:   from.Add(time.Duration(minute) * time.Minute),
+			CheckError: "socket: permission denied",
+		}
+	}
+	replied := func(minute int, avg, maxRtt float64) entity.Container {
+		c := check(minute, true)
+		c.PingStats = entity.PingStats{PacketsSent: 3, PacketsRecv: 3, AvgRtt: avg, MaxRtt: maxRtt}
+		return c
+	}
+	percent := func(v float64) *float64 {
+		return &v
+	}
+
+	tests := []struct {
+		name    string
+		results []entity.Container
+		want    entity.Uptime
+	}{
+		{
+			name:    "No data",
+			results: nil,
+			want:    entity.Uptime{From: from, To: to},
+		},
+		{
+			name:    "Always reachable",
+			results: []entity.Container{check(0, true), check(5, true)},
+			want:    entity.Uptime{From: from, To: to, Checks: 2, Up: 2, Availability: percent(100)},
+		},
+		{
+			name: "Resolved outages",
+			results: []entity.Container{
+				check(0, true), check(2, false), check(3, false), check(4, true),
+				check(6, false), check(7, true),
+			},
+			want: entity.Uptime{
+				From:          from,
+				To:            to,
+				Checks:        6,
+				Up:            3,
+				Down:          3,
+				Availability:  percent(70),
+				Outages:       2,
+				MTTR:          90 * time.Second,
+				LongestOutage: 2 * time.Minute,
+			},
+		},
+		{
+			name:    "Unknown results are neither up nor down",
+			results: []entity.Container{check(0, true), check(2, false), unknown(3), check(8, true)},
+			want: entity.Uptime{
+				From:          from,
+				To:            to,
+				Checks:        4,
+				Up:            2,
+				Down:          1,
+				Unknown:       1,
+				Availability:  percent(80),
+				Outages:       1,
+				MTTR:          6 * time.Minute,
+				LongestOutage: 6 * time.Minute,
+			},
+		},
+		{
+			name:    "Only unknown results",
+			results: []entity.Container{unknown(0), unknown(5)},
+			want:    entity.Uptime{From: from, To: to, Checks: 2, Unknown: 2},
+		},
+		{
+			name:    "Latency of replied checks",
+			results: []entity.Container{replied(0, 1, 2), check(2, false), replied(4, 3, 5)},
+			want: entity.Uptime{
+				From:          from,
+				To:            to,
+				Checks:        3,
+				Up:            2,
+				Down:          1,
+				Availability:  percent(80),
+				Outages:       1,
+				MTTR:          2 * time.Minute,
+				LongestOutage: 2 * time.Minute,
+				AvgRtt:        2,
+				MaxRtt:        5,
+			},
+		},
+		{
+			name:    "Ongoing outage (unsorted, out of range ignored)",
+			results: []entity.Container{check(5, false), check(0, true), check(-1, false)},
+			want: entity.Uptime{
+				From:          from,
+				To:            to,
+				Checks:        2,
+				Up:            1,
+				Down:          1,
+				Availability:  percent(50),
+				Outages:       1,
+				LongestOutage: 5 * time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, CalculateUptime(tt.results, from, to))
+		})
+	}
+}
@@ -24,9 +24,22 @@ const IpTable = () => {
         },
       });
       const formattedData = response.data.map(item => ({
+        key: item.key || item.ip_address,
+        name: item.container_name || '',
+        network: item.network || '',
         ip: item.ip_address,
+        family: item.ip_family || 'ipv4',
         isReachable: item.is_reachable,
         lastPing: item.last_ping,
+        avgRtt: item.avg_rtt,
+        packetLoss: item.packet_loss,
+        flapping: item.flapping,
+        inMaintenance: item.in_maintenance,
+        probes: item.probes || [],
+        status: item.status,
+        healthOutput: item.health_output,
+        goneAt: item.gone_at,
+        checkError: item.check_error,
       }));
       setData(formattedData);
       setError(null);
@@ -44,25 +57,83 @@ const IpTable = () => {
   }, []);
 
   const columns = [
+    {
+      title: 'Container',
+      dataIndex: 'name',
+      key: 'name',
+      sorter: (a, b) => a.name.localeCompare(b.name),
+    },
+    {
+      title: 'Network',
+      dataIndex: 'network',
+      key: 'network',
+    },
     {
       title: 'IP Address',
       dataIndex: 'ip',
       key: 'ip',
       sorter: (a, b) => a.ip.localeCompare(b.ip),
     },
+    {
+      title: 'Family',
+      dataIndex: 'family',
+      key: 'family',
+      filters: [
+        { text: 'IPv4', value: 'ipv4' },
+        { text: 'IPv6', value: 'ipv6' },
+      ],
+      onFilter: (value, record) => record.family === value,
+      render: value => <Tag>{value === 'ipv6' ? 'IPv6' : 'IPv4'}</Tag>,
+    },
     {
       title: 'Reachable',
       dataIndex: 'isReachable',
       key: 'isReachable',
-      render: (value) => (
-        value ? <Tag color="green">Yes</Tag> : <Tag color="red">No</Tag>
-      ),
+      render: (value, record) => (record.status === 'gone' ? (
+        <Tag color="default" title={`Removed at ${record.goneAt}`}>Gone</Tag>
+      ) : (
+        <>
+          {value && <Tag color="green">Yes</Tag>}
+          {!value && record.status === 'unknown' && <Tag color="default" title={record.checkError}>Unknown</Tag>}
+          {!value && record.status === 'maintenance' && <Tag color="blue">Maintenance</Tag>}
+          {!value && record.status === 'down' && <Tag color="red">No</Tag>}
+          {record.flapping && <Tag color="gold">Flapping</Tag>}
+          {record.status === 'unhealthy' && <Tag color="volcano" title={record.healthOutput}>Unhealthy</Tag>}
+          {record.status === 'starting' && <Tag color="cyan">Starting</Tag>}
+        </>
+      )),
       filters: [
         { text: 'Yes', value: true },
         { text: 'No', value: false },
       ],
       onFilter: (value, record) => record.isReachable === value,
     },
+    {
+      title: 'Avg RTT (ms)',
+      dataIndex: 'avgRtt',
+      key: 'avgRtt',
+      render: (value) => value.toFixed(2),
+      sorter: (a, b) => a.avgRtt - b.avgRtt,
+    },
+    {
+      title: 'Packet Loss',
+      dataIndex: 'packetLoss',
+      key: 'packetLoss',
+      render: (value) => (
+        value > 0 ? <Tag color="orange">{value.toFixed(0)}%</Tag> : `${value.toFixed(0)}%`
+      ),
+      sorter: (a, b) => a.packetLoss - b.packetLoss,
+    },
+    {
+      title: 'Probes',
+      dataIndex: 'probes',
+      key: 'probes',
+      render: (probes) => probes.map(probe => (
+        <Tag key={`${probe.type}/${probe.target || probe.port}`} color={probe.success ? 'green' : 'red'} title={probe.error}>
+          {probe.type}/{probe.type === 'dns' ? probe.target : probe.port}{probe.status_code ? ` ${probe.status_code}` : ''}{probe.status ? ` ${probe.status}` : ''}
+        </Tag>
+      )),
+    },
     {
       title: 'Last Ping',
       dataIndex: 'lastPing',
@@ -85,7 +156,7 @@ const IpTable = () => {
       <Table
         columns={columns}
         dataSource={data}
-        rowKey="ip"
+        rowKey="key"
         bordered
         pagination={{ pageSize: 10 }}
         scroll={{ x: true }}
@@ -2,20 +2,83 @@ package config
 
 import (
 	"app-pinger/pkg/config"
+	"errors"
+	"fmt"
 	"time"
 )
 
 type Config struct {
-	LogLevel     string        `env:"PINGER_LOG_LEVEL"`
-	PacketsCount int           `env:"PINGER_PACKETS_COUNT"`
-	PingTimeout  time.Duration `env:"PINGER_PING_TIMEOUT"`
-	SvcTimeout   time.Duration `env:"PINGER_SVC_PING_TIMEOUT" `
-	BackendName  string        `env:"BACKEND_HOST"`
-	ServiceName  string        `env:"PINGER_HOST"`
-	BackendPort  string        `env:"BACKEND_PORT"`
-	Network      string        `env:"PINGER_NETWORK"`
-	RabbitMQPath string
-	RabbitMQ     config.RabbitMQ
+	LogLevel       string        `env:"PINGER_LOG_LEVEL"`
+	PacketsCount   int           `env:"PINGER_PACKETS_COUNT"`
+	PingTimeout    time.Duration `env:"PINGER_PING_TIMEOUT"`
+	TCPTimeout     time.Duration `env:"PINGER_TCP_TIMEOUT" env-default:"2s"`
+	HTTPTimeout    time.Duration `env:"PINGER_HTTP_TIMEOUT" env-default:"5s"`
+	GRPCTimeout    time.Duration `env:"PINGER_GRPC_TIMEOUT" env-default:"5s"`
+	DNSTimeout     time.Duration `env:"PINGER_DNS_TIMEOUT" env-default:"2s"`
+	DNSServer      string        `env:"PINGER_DNS_SERVER"`
+	SvcTimeout     time.Duration `env:"PINGER_SVC_PING_TIMEOUT" `
+	Jitter         float64       `env:"PINGER_JITTER" env-default:"0.1"`
+	CycleDeadline  time.Duration `env:"PINGER_CYCLE_DEADLINE" env-default:"30s"`
+	Workers        int           `env:"PINGER_WORKERS" env-default:"32"`
+	QueueSize      int           `env:"PINGER_QUEUE_SIZE" env-default:"1024"`
+	MetricsAddr    string        `env:"PINGER_METRICS_ADDR" env-default:":8082"`
+	ResyncInterval time.Duration `env:"PINGER_RESYNC_INTERVAL" env-default:"5m"`
+	BackendName    string        `env:"BACKEND_HOST"`
+	ServiceName    string        `env:"PINGER_HOST"`
+	BackendPort    string        `env:"BACKEND_PORT"`
+	Network        string        `env:"PINGER_NETWORK"`
+	ConfigFile     string        `env:"PINGER_CONFIG_FILE" env-default:"pinger_config.yaml"`
+	ConfigPoll     time.Duration `env:"PINGER_CONFIG_POLL" env-default:"10s"`
+	RabbitMQPath   string
+	RabbitMQ       config.RabbitMQ
+	Filter         Filter
+	Access         Access
+}
+
+// Способы доступа pinger'а к сетям контейнеров
+const (
+	// AccessConnect pinger подключается к сетям контейнеров через Docker API
+	AccessConnect = "connect"
+	// AccessHost pinger запущен в сети хоста (network_mode: host) и проверяет контейнеры из нее
+	AccessHost = "host"
+	// AccessNetns pinger входит в сетевое пространство имен каждого контейнера по его PID,
+	// нужны pid: host (или /proc хоста в ProcPath) и CAP_SYS_ADMIN. ICMP-пинг здесь не подтверждает
+	// доступность, поэтому контейнеры без проверок сервиса получают неизвестный статус
+	AccessNetns = "netns"
+)
+
+// Режимы ICMP-пинга
+const (
+	// ICMPAuto режим выбирается проверкой при запуске, непривилегированный предпочтительнее
+	ICMPAuto = "auto"
+	// ICMPPrivileged raw-сокеты, нужен CAP_NET_RAW
+	ICMPPrivileged = "privileged"
+	// ICMPUnprivileged UDP-сокеты, группа pinger'а должна входить в net.ipv4.ping_group_range
+	ICMPUnprivileged = "unprivileged"
+)
+
+// Access способ доступа pinger'а к сетям контейнеров. Idle - через сколько pinger отключается
+// от сети без целей в режиме connect, ProcPath - путь к /proc хоста для режима netns,
+// ICMP - режим ICMP-пинга
+type Access struct {
+	Mode     string        `env:"PINGER_NETWORK_MODE" env-default:"connect"`
+	Idle     time.Duration `env:"PINGER_NETWORK_IDLE" env-default:"5m"`
+	ProcPath string        `env:"PINGER_PROC_PATH" env-default:"/proc"`
+	ICMP     string        `env:"PINGER_ICMP_MODE" env-default:"auto"`
+}
+
+// Filter правила отбора контейнеров для проверки, в переменных окружения списки задаются через запятую.
+// Образы и имена задаются glob-шаблонами, в которых "*" совпадает и с "/", сети - в формате CIDR
+type Filter struct {
+	OptIn           bool     `env:"PINGER_FILTER_OPT_IN" env-default:"false" yaml:"opt_in"`
+	Projects        []string `env:"PINGER_FILTER_PROJECTS" yaml:"projects"`
+	ExcludeProjects []string `env:"PINGER_FILTER_EXCLUDE_PROJECTS" yaml:"exclude_projects"`
+	Images          []string `env:"PINGER_FILTER_IMAGES" yaml:"images"`
+	ExcludeImages   []string `env:"PINGER_FILTER_EXCLUDE_IMAGES" yaml:"exclude_images"`
+	Names           []string `env:"PINGER_FILTER_NAMES" yaml:"names"`
+	ExcludeNames    []string `env:"PINGER_FILTER_EXCLUDE_NAMES" yaml:"exclude_names"`
+	CIDRs           []string `env:"PINGER_FILTER_CIDRS" yaml:"cidrs"`
+	ExcludeCIDRs    []string `env:"PINGER_FILTER_EXCLUDE_CIDRS" yaml:"exclude_cidrs"`
 }
 
 func ConfigLoad() *Config {
@@ -27,3 +90,38 @@ func ConfigLoad() *Config {
 
 	return &cfg
 }
+
+// Runtime возвращает настройки из переменных окружения, которые может переопределить файл настроек
+func (c *Config) Runtime() Runtime {
+	return Runtime{
+		Interval:     c.SvcTimeout,
+		Jitter:       c.Jitter,
+		Deadline:     c.CycleDeadline,
+		PacketsCount: c.PacketsCount,
+		PingTimeout:  c.PingTimeout,
+		Filter:       c.Filter,
+	}
+}
+
+func (a Access) Validate() error {
+	switch a.Mode {
+	case AccessConnect, AccessHost, AccessNetns:
+	default:
+		return fmt.Errorf("unknown network mode %q, want %s, %s or %s", a.Mode, AccessConnect, AccessHost, AccessNetns)
+	}
+
+	if a.Mode == AccessConnect && a.Idle <= 0 {
+		return errors.New("network idle timeout must be positive")
+	}
+	if a.Mode == AccessNetns && a.ProcPath == "" {
+		return errors.New("proc path must not be empty")
+	}
+
+	switch a.ICMP {
+	case ICMPAuto, ICMPPrivileged, ICMPUnprivileged:
+	default:
+		return fmt.Errorf("unknown icmp mode %q, want %s, %s or %s", a.ICMP, ICMPAuto, ICMPPrivileged, ICMPUnprivileged)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// Runtime настройки, которые применяются без перезапуска pinger. Значения по умолчанию берутся
+// из переменных окружения, файл настроек переопределяет заданные в нем поля. Interval - интервал
+// проверок по умолчанию, Jitter - доля интервала, на которую случайно смещается время проверки,
+// Deadline - время, за которое должны завершиться проверки одного цикла
+type Runtime struct {
+	Interval     time.Duration    `yaml:"interval"`
+	Jitter       float64          `yaml:"jitter"`
+	Deadline     time.Duration    `yaml:"cycle_deadline"`
+	PacketsCount int              `yaml:"packets_count"`
+	PingTimeout  time.Duration    `yaml:"ping_timeout"`
+	Filter       Filter           `yaml:"filter"`
+	Targets      []TargetOverride `yaml:"targets"`
+}
+
+// TargetOverride настройки отдельных целей: Match - glob-шаблон имени или ключа контейнера.
+// Labels дополняют метки контейнера, поэтому проверки сервиса задаются так же, как метками pinger.*
+type TargetOverride struct {
+	Match        string            `yaml:"match"`
+	PacketsCount int               `yaml:"packets_count"`
+	PingTimeout  time.Duration     `yaml:"ping_timeout"`
+	Interval     time.Duration     `yaml:"interval"`
+	Labels       map[string]string `yaml:"labels"`
+}
+
+// LoadRuntime читает файл настроек path поверх настроек base и проверяет результат
+func LoadRuntime(path string, base Runtime) (*Runtime, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	cfg := base
+	if err = yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+
+	if err = cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+func (r *Runtime) Validate() error {
+	if r.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if r.Jitter < 0 || r.Jitter >= 1 {
+		return errors.New("jitter must be in [0, 1)")
+	}
+	if r.Deadline < 0 {
+		return errors.New("cycle_deadline must not be negative")
+	}
+	if r.PacketsCount < 1 {
+		return errors.New("packets_count must be positive")
+	}
+	if r.PingTimeout <= 0 {
+		return errors.New("ping_timeout must be positive")
+	}
+
+	if err := r.Filter.Validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+
+	for i, target := range r.Targets {
+		if target.Match == "" {
+			return fmt.Errorf("target %d: empty match", i)
+		}
+		if _, err := path.Match(target.Match, ""); err != nil {
+			return fmt.Errorf("target %s: invalid match: %w", target.Match, err)
+		}
+		if target.PacketsCount < 0 {
+			return fmt.Errorf("target %s: packets_count must not be negative", target.Match)
+		}
+		if target.PingTimeout < 0 {
+			return fmt.Errorf("target %s: ping_timeout must not be negative", target.Match)
+		}
+		if target.Interval < 0 {
+			return fmt.Errorf("target %s: interval must not be negative", target.Match)
+		}
+	}
+
+	return nil
+}
+
+// Validate проверяет glob-шаблоны и CIDR фильтра, пустые элементы списков пропускаются
+func (f Filter) Validate() error {
+	for _, list := range [][]string{f.Images, f.ExcludeImages, f.Names, f.ExcludeNames} {
+		for _, pattern := range list {
+			if _, err := path.Match(strings.TrimSpace(pattern), ""); err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+
+	for _, list := range [][]string{f.CIDRs, f.ExcludeCIDRs} {
+		for _, cidr := range list {
+			if cidr = strings.TrimSpace(cidr); cidr == "" {
+				continue
+			}
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Watcher следит за файлом настроек path: раз в poll проверяет время его изменения и перечитывает.
+// Если файл некорректен, ошибка логируется и остаются действовать предыдущие настройки
+type Watcher struct {
+	path    string
+	base    Runtime
+	log     *slog.Logger
+	current atomic.Pointer[Runtime]
+	modTime time.Time
+	updates chan struct{}
+}
+
+// NewWatcher создает Watcher с настройками base и сразу читает файл path, если он есть
+func NewWatcher(path string, base Runtime, log *slog.Logger) *Watcher {
+	w := &Watcher{
+		path:    path,
+		base:    base,
+		log:     log,
+		updates: make(chan struct{}, 1),
+	}
+	w.current.Store(&base)
+	w.Reload()
+
+	return w
+}
+
+// Run проверяет изменения файла до отмены ctx
+func (w *Watcher) Run(ctx context.Context, poll time.Duration) {
+	if w.path == "" {
+		return
+	}
+
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Reload()
+		}
+	}
+}
+
+// Reload перечитывает файл, если он изменился с прошлой проверки
+func (w *Watcher) Reload() {
+	if w.path == "" {
+		return
+	}
+
+	info, err := os.Stat(w.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		w.log.Error("failed to stat config file", slog.String("path", w.path), slog.Any("error", err))
+		return
+	}
+	if info.ModTime().Equal(w.modTime) {
+		return
+	}
+	w.modTime = info.ModTime()
+
+	cfg, err := LoadRuntime(w.path, w.base)
+	if err != nil {
+		w.log.Error("failed to reload config, keeping previous", slog.Any("error", err))
+		return
+	}
+
+	w.current.Store(cfg)
+	w.log.Info("config reloaded", slog.String("path", w.path))
+
+	select {
+	case w.updates <- struct{}{}:
+	default:
+	}
+}
+
+// Config возвращает действующие настройки
+func (w *Watcher) Config() *Runtime {
+	return w.current.Load()
+}
+
+// Updates возвращает канал, в который приходит сигнал после применения новых настроек
+func (w *Watcher) Updates() <-chan struct{} {
+	return w.updates
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pinger_config.yaml")
+	base := Runtime{Interval: 15 * time.Second, PacketsCount: 4, PingTimeout: 5 * time.Second,
+		Filter: Filter{ExcludeNames: []string{"tools"}}}
+
+	write := func(content string, modTime time.Time) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	// без файла действуют настройки из окружения
+	w := NewWatcher(path, base, slog.Default())
+	require.Equal(t, base, *w.Config())
+
+	now := time.Now()
+	write("interval: 30s\ntargets:\n  - match: app-*\n    packets_count: 2\n", now)
+	w.Reload()
+	require.Len(t, w.Updates(), 1)
+	<-w.Updates()
+	require.Equal(t, 30*time.Second, w.Config().Interval)
+	require.Equal(t, 4, w.Config().PacketsCount)
+	require.Equal(t, []string{"tools"}, w.Config().Filter.ExcludeNames)
+	require.Equal(t, []TargetOverride{{Match: "app-*", PacketsCount: 2}}, w.Config().Targets)
+
+	// некорректный файл не применяется
+	write("interval: -1s\n", now.Add(time.Second))
+	w.Reload()
+	require.Empty(t, w.Updates())
+	require.Equal(t, 30*time.Second, w.Config().Interval)
+
+	write("filter:\n  cidrs: [172.20.0.0]\n", now.Add(2*time.Second))
+	w.Reload()
+	require.Equal(t, 30*time.Second, w.Config().Interval)
+
+	write("interval: [\n", now.Add(3*time.Second))
+	w.Reload()
+	require.Equal(t, 30*time.Second, w.Config().Interval)
+
+	// неизмененный файл не перечитывается
+	write("interval: 1m\n", now.Add(3*time.Second))
+	w.Reload()
+	require.Equal(t, 30*time.Second, w.Config().Interval)
+
+	write("interval: 1m\n", now.Add(4*time.Second))
+	w.Reload()
+	require.Equal(t, time.Minute, w.Config().Interval)
+	require.Empty(t, w.Config().Targets)
+}
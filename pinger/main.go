@@ -6,36 +6,49 @@ import (
 	"app-pinger/pkg/contracts"
 	"app-pinger/pkg/loger"
 	queue "app-pinger/pkg/queue"
-	_ "embed"
+	"context"
+	"expvar"
 	"github.com/docker/docker/client"
 	"log/slog"
-	"strings"
-	"sync"
+	"net/http"
+	"os"
 	"time"
 )
 
-//go:embed list.txt
-var filterList string
+const (
+	// schedulerTick точность планировщика проверок
+	schedulerTick = time.Second
+	resultsBuffer = 256
+)
 
 func main() {
 	cfg := config.ConfigLoad()
 
-	containerACL := strings.Split(filterList, "\n")
-	whiteList := true
+	log := loger.SetupLogger(cfg.LogLevel)
 
-	var list []string
-	if len(containerACL) > 0 {
-		if containerACL[0] == "black" {
-			whiteList = false
-		}
+	log.Info("starting pinger-server")
+	log.Debug("debug message are enabled")
 
-		list = containerACL[1:]
+	if err := cfg.Access.Validate(); err != nil {
+		log.Error("invalid network access settings", slog.Any("error", err))
+		os.Exit(1)
 	}
 
-	log := loger.SetupLogger(cfg.LogLevel)
+	base := cfg.Runtime()
+	if err := base.Validate(); err != nil {
+		log.Error("invalid pinger settings", slog.Any("error", err))
+		os.Exit(1)
+	}
 
-	log.Info("starting pinger-server")
-	log.Debug("debug message are enabled")
+	// настройки из файла применяются без перезапуска, при ошибке остаются предыдущие
+	watcher := config.NewWatcher(cfg.ConfigFile, base, log)
+	settings := watcher.Config()
+
+	filter, err := service.NewFilter(settings.Filter)
+	if err != nil {
+		log.Error("failed to create container filter", slog.Any("error", err))
+		os.Exit(1)
+	}
 
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
@@ -50,46 +63,157 @@ func main() {
 	}
 	defer rabbitMQ.Close()
 
-	pinger := service.NewPingerService(service.NewGoPingerService(cli, log, cfg.PacketsCount, cfg.PingTimeout, cfg.ServiceName, rabbitMQ))
+	eventsMQ, err := queue.NewConnection(cfg.RabbitMQPath, cfg.RabbitMQ.EventsQueue)
+	if err != nil {
+		log.Error("failed to create rabbitMQ events connection", slog.Any("error", err))
+	}
+	defer eventsMQ.Close()
+
+	pinger := service.NewPingerService(service.NewGoPingerService(cli, log, cfg.PacketsCount, cfg.PingTimeout,
+		cfg.ResyncInterval, cfg.Access, cfg.ServiceName, rabbitMQ, eventsMQ, service.NewTCPProber(cfg.TCPTimeout),
+		service.NewHTTPProber(cfg.HTTPTimeout), service.NewGRPCProber(cfg.GRPCTimeout),
+		service.NewDNSProber(cfg.DNSTimeout, cfg.DNSServer)))
 
 	log.Info("pinger-server started")
-	log.Debug("service settings", slog.Any("service-timeout", cfg.SvcTimeout),
-		slog.Any("ping-packets", cfg.PacketsCount), slog.Any("ping-timeout", cfg.PingTimeout),
-		slog.Any("network", cfg.Network))
-
-	reach := make(map[string]contracts.PingData)
+	log.Debug("service settings", slog.Any("interval", settings.Interval), slog.Any("jitter", settings.Jitter),
+		slog.Any("ping-packets", settings.PacketsCount), slog.Any("ping-timeout", settings.PingTimeout),
+		slog.Any("tcp-timeout", cfg.TCPTimeout), slog.Any("http-timeout", cfg.HTTPTimeout),
+		slog.Any("grpc-timeout", cfg.GRPCTimeout), slog.Any("dns-timeout", cfg.DNSTimeout),
+		slog.Any("network", cfg.Network), slog.Any("resync-interval", cfg.ResyncInterval),
+		slog.String("network-mode", cfg.Access.Mode), slog.Any("network-idle", cfg.Access.Idle),
+		slog.Any("filter", settings.Filter), slog.String("config-file", cfg.ConfigFile),
+		slog.Int("workers", cfg.Workers), slog.Any("cycle-deadline", settings.Deadline),
+		slog.String("metrics-address", cfg.MetricsAddr))
+
+	go pinger.Watch(context.Background())
+	go watcher.Run(context.Background(), cfg.ConfigPoll)
+
+	// события жизненного цикла отправляются сразу, независимо от проверок
+	go func() {
+		for events := range pinger.Events() {
+			if err := pinger.SendEvents(events); err != nil {
+				log.Error("failed to send events", slog.Any("error", err))
+			}
+		}
+	}()
 
-	ticker := time.NewTicker(cfg.SvcTimeout)
-	for range ticker.C {
-		netIPs := pinger.GetIPs(list, whiteList)
+	results := make(chan contracts.PingData, resultsBuffer)
+	go publish(pinger, results, log)
 
-		var wg sync.WaitGroup
-		var mutex = &sync.Mutex{}
+	scheduler := service.NewScheduler()
+	executor := service.NewExecutor(cfg.Workers, cfg.QueueSize, pinger.Ping, results, scheduler.Done)
+	go executor.Run(context.Background())
 
-		for net, ips := range netIPs {
-			for _, ip := range ips {
-				wg.Add(1)
+	if cfg.MetricsAddr != "" {
+		expvar.Publish("pinger", expvar.Func(func() any { return executor.Metrics() }))
+		go func() {
+			if err := http.ListenAndServe(cfg.MetricsAddr, nil); err != nil {
+				log.Error("failed to serve metrics", slog.Any("error", err))
+			}
+		}()
+	}
 
-				go func(net, ip string) {
-					defer wg.Done()
-					data := pinger.Ping(net, ip)
-					mutex.Lock()
-					reach[ip] = data
+	ticker := time.NewTicker(schedulerTick)
+	for {
+		select {
+		case <-watcher.Updates():
+			settings = watcher.Config()
+			if f, err := service.NewFilter(settings.Filter); err == nil {
+				// цели, исключенные новым фильтром, больше не проверяются, backend помечает их удаленными
+				excluded := excludedTargets(pinger.GetTargets(filter), pinger.GetTargets(f))
+				filter = f
+				if len(excluded) > 0 {
+					if err := pinger.SendEvents(service.Tombstones(excluded, time.Now())); err != nil {
+						log.Error("failed to send events", slog.Any("error", err))
+					}
+				}
+			}
+			log.Debug("settings applied", slog.Any("settings", settings))
+			continue
+		case stopped := <-pinger.Stopped():
+			// упавший или остановленный контейнер больше не проверяется, backend получает его недоступность
+			for _, target := range stopped {
+				if filter.Match(target) {
+					results <- service.StoppedPingData(target, time.Now())
+				}
+			}
+			continue
+		case id := <-pinger.Updates():
+			// новый или измененный контейнер проверяется сразу, не дожидаясь своего интервала
+			for _, targets := range containerTargets(pinger.GetTargets(filter), id) {
+				for _, target := range targets {
+					scheduler.Trigger(target.Key)
+				}
+			}
+		case <-ticker.C:
+		}
 
-					mutex.Unlock()
-				}(net, ip)
+		var targets []service.Target
+		netTargets := pinger.GetTargets(filter)
+		for _, networkTargets := range netTargets {
+			for _, target := range networkTargets {
+				targets = append(targets, service.ApplyRuntime(target, settings))
 			}
-			wg.Wait()
 		}
-		wg.Wait()
-		pingArr := make([]contracts.PingData, 0, len(reach))
-		for _, v := range reach {
-			pingArr = append(pingArr, v)
+		pinger.ReleaseNetworks(netTargets)
+
+		executor.Submit(scheduler.Due(targets, settings.Jitter, time.Now()), settings.Deadline)
+	}
+}
+
+// publish отправляет результаты проверок по мере их завершения, результаты, накопившиеся
+// к моменту отправки, объединяются в одно сообщение
+func publish(pinger *service.PingerSvc, results <-chan contracts.PingData, log *slog.Logger) {
+	for data := range results {
+		batch := []contracts.PingData{data}
+
+	collect:
+		for {
+			select {
+			case data = <-results:
+				batch = append(batch, data)
+			default:
+				break collect
+			}
 		}
 
-		err = pinger.SendRequest(pingArr)
-		if err != nil {
+		if err := pinger.SendRequest(batch); err != nil {
 			log.Error("failed to send request", slog.Any("error", err))
 		}
 	}
 }
+
+// containerTargets оставляет в netTargets только цели контейнера id
+func containerTargets(netTargets map[string][]service.Target, id string) map[string][]service.Target {
+	filtered := map[string][]service.Target{}
+	for net, targets := range netTargets {
+		for _, t := range targets {
+			if t.ContainerID == id {
+				filtered[net] = append(filtered[net], t)
+			}
+		}
+	}
+
+	return filtered
+}
+
+// excludedTargets возвращает цели из previous, которых нет в current
+func excludedTargets(previous, current map[string][]service.Target) []service.Target {
+	keys := map[string]struct{}{}
+	for _, targets := range current {
+		for _, t := range targets {
+			keys[t.Key] = struct{}{}
+		}
+	}
+
+	var excluded []service.Target
+	for _, targets := range previous {
+		for _, t := range targets {
+			if _, ok := keys[t.Key]; !ok {
+				excluded = append(excluded, t)
+			}
+		}
+	}
+
+	return excluded
+}
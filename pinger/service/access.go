@@ -0,0 +1,45 @@
+package service
+
+import (
+	"time"
+)
+
+// NetAccess способ, которым pinger получает доступ к сети цели
+type NetAccess interface {
+	// Prepare готовит доступ к сети цели t перед проверками сервиса: подключает pinger к сети
+	// или задает t.Dial
+	Prepare(t Target) (Target, error)
+	// Run выполняет ICMP-пинг fn в сети цели t
+	Run(t Target, fn func() error) error
+	// Release освобождает сети, в которых нет целей active, и возвращает их
+	Release(active []string, now time.Time) []string
+	// Forget забывает удаленную сеть net
+	Forget(net string)
+	// ICMPReliable сообщает, подтверждает ли ответ на ICMP-пинг доступность цели из сети pinger'а
+	ICMPReliable() bool
+}
+
+// HostAccess доступ из сети хоста: pinger запущен с network_mode: host, и адреса контейнеров
+// в bridge-сетях доступны ему напрямую
+type HostAccess struct{}
+
+// check for implementation
+var _ NetAccess = HostAccess{}
+
+func (HostAccess) Prepare(t Target) (Target, error) {
+	return t, nil
+}
+
+func (HostAccess) Run(t Target, fn func() error) error {
+	return fn()
+}
+
+func (HostAccess) Release(active []string, now time.Time) []string {
+	return nil
+}
+
+func (HostAccess) Forget(net string) {}
+
+func (HostAccess) ICMPReliable() bool {
+	return true
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Executor выполняет проверки целей пулом из workers горутин, поэтому одновременно открыто
+// не больше workers ICMP-сокетов. Проверки, отправленные одним вызовом Submit, составляют цикл:
+// если проверка цикла не успела начаться до его дедлайна или очередь заполнена, она пропускается,
+// а начавшаяся проверка прерывается на дедлайне цикла. Проверка, завершившаяся позже интервала
+// своей цели после отправки, считается опоздавшей
+type Executor struct {
+	workers int
+	jobs    chan job
+	check   func(ctx context.Context, t Target) contracts.PingData
+	results chan<- contracts.PingData
+	done    func(key string)
+
+	busy      atomic.Int64
+	checks    atomic.Int64
+	skipped   atomic.Int64
+	overruns  atomic.Int64
+	cycles    atomic.Int64
+	lastCycle atomic.Int64
+	maxCycle  atomic.Int64
+}
+
+type job struct {
+	target Target
+	cycle  *cycle
+}
+
+// cycle проверки одного вызова Submit, pending - количество незавершенных проверок
+type cycle struct {
+	started  time.Time
+	deadline time.Time
+	pending  atomic.Int64
+}
+
+// context возвращает контекст проверки цикла, который отменяется на его дедлайне
+func (c *cycle) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithDeadline(ctx, c.deadline)
+}
+
+// NewExecutor создает пул проверок check, результаты отправляются в results, после завершения
+// или пропуска проверки цели вызывается done с ее ключом
+func NewExecutor(
+	workers int,
+	queueSize int,
+	check func(ctx context.Context, t Target) contracts.PingData,
+	results chan<- contracts.PingData,
+	done func(key string),
+) *Executor {
+	return &Executor{
+		workers: max(workers, 1),
+		jobs:    make(chan job, max(queueSize, 1)),
+		check:   check,
+		results: results,
+		done:    done,
+	}
+}
+
+// Run запускает обработчиков проверок и ждет отмены ctx
+func (e *Executor) Run(ctx context.Context) {
+	for i := 0; i < e.workers; i++ {
+		go e.work(ctx)
+	}
+	<-ctx.Done()
+}
+
+// Submit ставит в очередь проверки целей targets одним циклом с дедлайном deadline,
+// нулевой deadline отключает дедлайн
+func (e *Executor) Submit(targets []Target, deadline time.Duration) {
+	if len(targets) == 0 {
+		return
+	}
+
+	c := &cycle{started: time.Now()}
+	if deadline > 0 {
+		c.deadline = c.started.Add(deadline)
+	}
+	c.pending.Store(int64(len(targets)))
+
+	for _, t := range targets {
+		select {
+		case e.jobs <- job{target: t, cycle: c}:
+		default:
+			e.skip(job{target: t, cycle: c})
+		}
+	}
+}
+
+func (e *Executor) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-e.jobs:
+			if !j.cycle.deadline.IsZero() && time.Now().After(j.cycle.deadline) {
+				e.skip(j)
+				continue
+			}
+
+			checkCtx, cancel := j.cycle.context(ctx)
+			e.busy.Add(1)
+			data := e.check(checkCtx, j.target)
+			e.busy.Add(-1)
+			cancel()
+			e.checks.Add(1)
+
+			if interval := j.target.Interval; interval > 0 && time.Since(j.cycle.started) > interval {
+				e.overruns.Add(1)
+			}
+
+			e.results <- data
+			e.finish(j)
+		}
+	}
+}
+
+func (e *Executor) skip(j job) {
+	e.skipped.Add(1)
+	e.finish(j)
+}
+
+// finish отмечает завершение проверки и фиксирует длительность цикла после его последней проверки
+func (e *Executor) finish(j job) {
+	e.done(j.target.Key)
+
+	if j.cycle.pending.Add(-1) > 0 {
+		return
+	}
+
+	duration := int64(time.Since(j.cycle.started))
+	e.cycles.Add(1)
+	e.lastCycle.Store(duration)
+	for {
+		current := e.maxCycle.Load()
+		if duration <= current || e.maxCycle.CompareAndSwap(current, duration) {
+			break
+		}
+	}
+}
+
+// Metrics возвращает метрики пула: глубину очереди, занятость обработчиков, количество проверок
+// (в том числе опоздавших на интервал цели) и длительность циклов в миллисекундах
+func (e *Executor) Metrics() map[string]interface{} {
+	return map[string]interface{}{
+		"queue_depth":    len(e.jobs),
+		"queue_size":     cap(e.jobs),
+		"workers":        e.workers,
+		"workers_busy":   e.busy.Load(),
+		"checks":         e.checks.Load(),
+		"checks_skipped": e.skipped.Load(),
+		"checks_overrun": e.overruns.Load(),
+		"cycles":         e.cycles.Load(),
+		"last_cycle_ms":  toMilliseconds(time.Duration(e.lastCycle.Load())),
+		"max_cycle_ms":   toMilliseconds(time.Duration(e.maxCycle.Load())),
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecutor(t *testing.T) {
+	var running, peak atomic.Int64
+	release := make(chan struct{})
+
+	check := func(ctx context.Context, t Target) contracts.PingData {
+		n := running.Add(1)
+		for {
+			current := peak.Load()
+			if n <= current || peak.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		<-release
+		running.Add(-1)
+
+		return contracts.PingData{IPAddress: t.IP}
+	}
+
+	var mu sync.Mutex
+	var done []string
+	results := make(chan contracts.PingData, 16)
+
+	e := NewExecutor(2, 4, check, results, func(key string) {
+		mu.Lock()
+		done = append(done, key)
+		mu.Unlock()
+	})
+
+	var targets []Target
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		targets = append(targets, Target{IP: key, ContainerInfo: contracts.ContainerInfo{Key: key}})
+	}
+
+	// очередь вмещает 4 проверки, для остальных места нет
+	e.Submit(targets[:5], 0)
+	e.Submit(targets[5:], 0)
+	require.Equal(t, int64(3), e.Metrics()["checks_skipped"])
+	require.Equal(t, 4, e.Metrics()["queue_depth"])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	// одновременно выполняется не больше 2 проверок
+	require.Eventually(t, func() bool { return running.Load() == 2 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, int64(2), running.Load())
+
+	close(release)
+	for i := 0; i < 4; i++ {
+		<-results
+	}
+
+	require.Eventually(t, func() bool { return e.Metrics()["cycles"] == int64(2) }, time.Second, time.Millisecond)
+	require.Equal(t, int64(2), peak.Load())
+	require.Equal(t, int64(4), e.Metrics()["checks"])
+
+	mu.Lock()
+	require.ElementsMatch(t, []string{"a", "b", "c", "d", "e", "f", "g"}, done)
+	mu.Unlock()
+}
+
+func TestExecutor_Deadline(t *testing.T) {
+	release := make(chan struct{})
+	check := func(ctx context.Context, t Target) contracts.PingData {
+		<-release
+		return contracts.PingData{IPAddress: t.IP}
+	}
+
+	results := make(chan contracts.PingData, 4)
+	e := NewExecutor(1, 4, check, results, func(key string) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	// вторая проверка не успевает начаться до дедлайна цикла и пропускается
+	e.Submit([]Target{{IP: "a"}, {IP: "b"}}, 200*time.Millisecond)
+	time.Sleep(250 * time.Millisecond)
+	close(release)
+
+	require.Equal(t, "a", (<-results).IPAddress)
+	require.Eventually(t, func() bool { return e.Metrics()["cycles"] == int64(1) }, time.Second, time.Millisecond)
+	require.Equal(t, int64(1), e.Metrics()["checks_skipped"])
+	require.Empty(t, results)
+}
+
+func TestExecutor_DeadlineInterruptsCheck(t *testing.T) {
+	check := func(ctx context.Context, t Target) contracts.PingData {
+		<-ctx.Done()
+		return contracts.PingData{IPAddress: t.IP, CheckError: ctx.Err().Error()}
+	}
+
+	results := make(chan contracts.PingData, 1)
+	e := NewExecutor(1, 1, check, results, func(key string) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	// начавшаяся проверка прерывается на дедлайне цикла, а не выполняется до своего таймаута
+	e.Submit([]Target{{IP: "a"}}, 50*time.Millisecond)
+
+	select {
+	case data := <-results:
+		require.Equal(t, context.DeadlineExceeded.Error(), data.CheckError)
+	case <-time.After(time.Second):
+		t.Fatal("check was not interrupted at the cycle deadline")
+	}
+}
+
+func TestExecutor_Overrun(t *testing.T) {
+	check := func(ctx context.Context, t Target) contracts.PingData {
+		time.Sleep(30 * time.Millisecond)
+		return contracts.PingData{IPAddress: t.IP}
+	}
+
+	results := make(chan contracts.PingData, 2)
+	e := NewExecutor(2, 2, check, results, func(key string) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	// проверка цели с интервалом 10ms завершается позже следующей запланированной
+	e.Submit([]Target{{IP: "fast", Interval: 10 * time.Millisecond}, {IP: "slow", Interval: time.Minute}}, 0)
+	<-results
+	<-results
+
+	require.Eventually(t, func() bool { return e.Metrics()["cycles"] == int64(1) }, time.Second, time.Millisecond)
+	require.Equal(t, int64(1), e.Metrics()["checks_overrun"])
+}
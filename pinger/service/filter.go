@@ -0,0 +1,142 @@
+package service
+
+import (
+	"app-pinger/pinger/config"
+	"fmt"
+	"net"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	enableLabel = "pinger.enable"
+	ignoreLabel = "pinger.ignore"
+	// globSeparator заменяет "/" в шаблонах и значениях перед path.Match, чтобы "*" и "?" захватывали
+	// и "/": образы с пространством имен (registry.local/team/app) подходят под registry.local/*
+	globSeparator = "\x00"
+)
+
+// Filter отбирает цели для проверки по меткам контейнера, compose-проекту, образу, имени и сети.
+// Метка pinger.ignore=true или pinger.enable=false всегда исключает контейнер, в режиме OptIn
+// проверяются только контейнеры с pinger.enable=true. Исключающие правила важнее включающих,
+// непустой включающий список требует совпадения хотя бы с одним его элементом
+type Filter struct {
+	optIn           bool
+	projects        []string
+	excludeProjects []string
+	images          []string
+	excludeImages   []string
+	names           []string
+	excludeNames    []string
+	networks        []*net.IPNet
+	excludeNetworks []*net.IPNet
+}
+
+// NewFilter создает фильтр по настройкам cfg, возвращает ошибку при некорректном шаблоне или CIDR
+func NewFilter(cfg config.Filter) (*Filter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	f := &Filter{
+		optIn:           cfg.OptIn,
+		projects:        cleanList(cfg.Projects),
+		excludeProjects: cleanList(cfg.ExcludeProjects),
+		images:          cleanList(cfg.Images),
+		excludeImages:   cleanList(cfg.ExcludeImages),
+		names:           cleanList(cfg.Names),
+		excludeNames:    cleanList(cfg.ExcludeNames),
+	}
+
+	var err error
+	if f.networks, err = parseCIDRs(cleanList(cfg.CIDRs)); err != nil {
+		return nil, err
+	}
+	if f.excludeNetworks, err = parseCIDRs(cleanList(cfg.ExcludeCIDRs)); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Match сообщает, нужно ли проверять цель t
+func (f *Filter) Match(t Target) bool {
+	if ignore, _ := strconv.ParseBool(t.Labels[ignoreLabel]); ignore {
+		return false
+	}
+
+	if value, ok := t.Labels[enableLabel]; ok {
+		if enable, _ := strconv.ParseBool(value); !enable {
+			return false
+		}
+	} else if f.optIn {
+		return false
+	}
+
+	if slices.Contains(f.excludeProjects, t.Project) || matchGlob(f.excludeImages, t.Image) ||
+		matchGlob(f.excludeNames, t.Name) || containsIP(f.excludeNetworks, t.IP) {
+		return false
+	}
+
+	return (len(f.projects) == 0 || slices.Contains(f.projects, t.Project)) &&
+		(len(f.images) == 0 || matchGlob(f.images, t.Image)) &&
+		(len(f.names) == 0 || matchGlob(f.names, t.Name)) &&
+		(len(f.networks) == 0 || containsIP(f.networks, t.IP))
+}
+
+// cleanList убирает пробелы вокруг элементов списка и пустые элементы
+func cleanList(list []string) []string {
+	cleaned := make([]string, 0, len(list))
+	for _, item := range list {
+		if item = strings.TrimSpace(item); item != "" {
+			cleaned = append(cleaned, item)
+		}
+	}
+
+	return cleaned
+}
+
+// parseCIDRs разбирает список сетей в формате CIDR
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// matchGlob сообщает, подходит ли value хотя бы под один шаблон patterns. Шаблоны разбираются
+// path.Match, но "*" и "?" совпадают и с "/"
+func matchGlob(patterns []string, value string) bool {
+	value = strings.ReplaceAll(value, "/", globSeparator)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ReplaceAll(pattern, "/", globSeparator), value); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsIP сообщает, входит ли IP хотя бы в одну из сетей networks
+func containsIP(networks []*net.IPNet, IP string) bool {
+	ip := net.ParseIP(IP)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
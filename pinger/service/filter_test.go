@@ -0,0 +1,111 @@
+package service
+
+import (
+	"app-pinger/pinger/config"
+	"app-pinger/pkg/contracts"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFilter_Match(t *testing.T) {
+	newTarget := func(IP string, info contracts.ContainerInfo) Target {
+		return Target{IP: IP, ContainerInfo: info}
+	}
+
+	web := newTarget("172.10.0.2", contracts.ContainerInfo{Name: "app-web-1", Image: "nginx:1.27", Project: "app"})
+	db := newTarget("172.20.0.2", contracts.ContainerInfo{Name: "app-db-1", Image: "postgres:16.6", Project: "app"})
+	ignored := newTarget("172.10.0.3", contracts.ContainerInfo{Name: "tools", Image: "busybox", Project: "ops",
+		Labels: map[string]string{ignoreLabel: "true"}})
+	enabled := newTarget("172.10.0.4", contracts.ContainerInfo{Name: "api", Image: "registry.local/api:1", Project: "ops",
+		Labels: map[string]string{enableLabel: "true"}})
+	disabled := newTarget("172.10.0.5", contracts.ContainerInfo{Name: "cron", Image: "alpine", Project: "ops",
+		Labels: map[string]string{enableLabel: "false"}})
+	namespaced := newTarget("172.10.0.6", contracts.ContainerInfo{Name: "worker",
+		Image: "registry.local/team/worker:2", Project: "ops"})
+
+	all := []Target{web, db, ignored, enabled, disabled, namespaced}
+
+	tests := []struct {
+		name string
+		cfg  config.Filter
+		want []string
+	}{
+		{
+			name: "No rules (labels only)",
+			cfg:  config.Filter{},
+			want: []string{"app-web-1", "app-db-1", "api", "worker"},
+		},
+		{
+			name: "Opt-in",
+			cfg:  config.Filter{OptIn: true},
+			want: []string{"api"},
+		},
+		{
+			name: "Project include",
+			cfg:  config.Filter{Projects: []string{"app"}},
+			want: []string{"app-web-1", "app-db-1"},
+		},
+		{
+			name: "Image glob exclude",
+			cfg:  config.Filter{ExcludeImages: []string{"postgres:*", "registry.local/*"}},
+			want: []string{"app-web-1"},
+		},
+		{
+			name: "Image glob crosses namespaces",
+			cfg:  config.Filter{Images: []string{"registry.local/*"}},
+			want: []string{"api", "worker"},
+		},
+		{
+			name: "Image glob with namespace wildcard",
+			cfg:  config.Filter{ExcludeImages: []string{"*/worker:*"}},
+			want: []string{"app-web-1", "app-db-1", "api"},
+		},
+		{
+			name: "Name glob include and CIDR exclude",
+			cfg:  config.Filter{Names: []string{"app-*"}, ExcludeCIDRs: []string{"172.20.0.0/16"}},
+			want: []string{"app-web-1"},
+		},
+		{
+			name: "CIDR include with empty list items",
+			cfg:  config.Filter{CIDRs: []string{"172.20.0.0/16", " "}, Projects: []string{""}},
+			want: []string{"app-db-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFilter(tt.cfg)
+			require.NoError(t, err)
+
+			var names []string
+			for _, target := range all {
+				if f.Match(target) {
+					names = append(names, target.Name)
+				}
+			}
+
+			require.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestNewFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Filter
+	}{
+		{
+			name: "Invalid CIDR",
+			cfg:  config.Filter{CIDRs: []string{"172.20.0.0"}},
+		},
+		{
+			name: "Invalid glob",
+			cfg:  config.Filter{ExcludeImages: []string{"nginx["}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFilter(tt.cfg)
+			require.Error(t, err)
+		})
+	}
+}
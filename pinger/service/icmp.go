@@ -0,0 +1,85 @@
+package service
+
+import (
+	"app-pinger/pinger/config"
+	"errors"
+	"fmt"
+	"github.com/go-ping/ping"
+	"time"
+)
+
+// Адреса loopback, которые пингуются при проверке режимов ICMP
+const (
+	loopback     = "127.0.0.1"
+	loopbackIPv6 = "::1"
+)
+
+// ICMPSelfTest результат проверки режимов ICMP-пинга на loopback, nil означает, что режим работает
+type ICMPSelfTest struct {
+	Privileged   error
+	Unprivileged error
+}
+
+// TestICMP пингует loopback в привилегированном (raw-сокет) и непривилегированном (UDP-сокет) режимах
+func TestICMP(timeout time.Duration) ICMPSelfTest {
+	return ICMPSelfTest{
+		Privileged:   pingLoopback(loopback, true, timeout),
+		Unprivileged: pingLoopback(loopback, false, timeout),
+	}
+}
+
+// TestICMPv6 пингует IPv6 loopback в выбранном режиме privileged: ICMPv6 может быть недоступен,
+// даже если IPv4 работает (IPv6 отключен в контейнере pinger'а, не тот ping_group_range)
+func TestICMPv6(privileged bool, timeout time.Duration) error {
+	return pingLoopback(loopbackIPv6, privileged, timeout)
+}
+
+// pingLoopback отправляет один пакет на адрес addr в режиме privileged
+func pingLoopback(addr string, privileged bool, timeout time.Duration) error {
+	pinger, err := ping.NewPinger(addr)
+	if err != nil {
+		return err
+	}
+
+	pinger.SetPrivileged(privileged)
+	pinger.Count = 1
+	pinger.Timeout = timeout
+
+	if err = pinger.Run(); err != nil {
+		return err
+	}
+	if pinger.Statistics().PacketsRecv == 0 {
+		return errors.New("no reply from loopback")
+	}
+
+	return nil
+}
+
+// Mode выбирает режим ICMP по настройке mode (config.ICMP*). В режиме auto предпочитается
+// непривилегированный режим. Возвращает ошибку, если выбранный режим не работает
+func (s ICMPSelfTest) Mode(mode string) (privileged bool, err error) {
+	switch mode {
+	case config.ICMPPrivileged:
+		return true, s.Privileged
+	case config.ICMPUnprivileged:
+		return false, s.Unprivileged
+	}
+
+	switch {
+	case s.Unprivileged == nil:
+		return false, nil
+	case s.Privileged == nil:
+		return true, nil
+	}
+
+	return false, fmt.Errorf("privileged: %v; unprivileged: %v", s.Privileged, s.Unprivileged)
+}
+
+// selfTestResult описание результата проверки режима для лога
+func selfTestResult(err error) string {
+	if err != nil {
+		return err.Error()
+	}
+
+	return "ok"
+}
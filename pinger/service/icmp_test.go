@@ -0,0 +1,58 @@
+package service
+
+import (
+	"app-pinger/pinger/config"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestICMPSelfTest_Mode(t *testing.T) {
+	denied := errors.New("socket: permission denied")
+
+	tests := []struct {
+		name       string
+		test       ICMPSelfTest
+		mode       string
+		privileged bool
+		wantErr    bool
+	}{
+		{
+			name: "Auto prefers unprivileged",
+			test: ICMPSelfTest{},
+			mode: config.ICMPAuto,
+		},
+		{
+			name:       "Auto falls back to privileged",
+			test:       ICMPSelfTest{Unprivileged: denied},
+			mode:       config.ICMPAuto,
+			privileged: true,
+		},
+		{
+			name:    "Auto without working mode",
+			test:    ICMPSelfTest{Privileged: denied, Unprivileged: denied},
+			mode:    config.ICMPAuto,
+			wantErr: true,
+		},
+		{
+			name:       "Privileged is not working",
+			test:       ICMPSelfTest{Privileged: denied},
+			mode:       config.ICMPPrivileged,
+			privileged: true,
+			wantErr:    true,
+		},
+		{
+			name: "Unprivileged",
+			test: ICMPSelfTest{Privileged: denied},
+			mode: config.ICMPUnprivileged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privileged, err := tt.test.Mode(tt.mode)
+
+			require.Equal(t, tt.privileged, privileged)
+			require.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
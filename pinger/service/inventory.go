@@ -0,0 +1,398 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// reconnectDelay задержка перед повторной подпиской на события Docker после ошибки
+const reconnectDelay = 5 * time.Second
+
+// dockerClient методы Docker SDK, которые использует Inventory
+type dockerClient interface {
+	ContainerList(ctx context.Context, options containertypes.ListOptions) ([]types.Container, error)
+	ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error)
+	Events(ctx context.Context, options events.ListOptions) (<-chan events.Message, <-chan error)
+}
+
+// lifecycleActions действия Docker, о которых сообщается backend'у как о событиях жизненного цикла
+var lifecycleActions = map[events.Action]string{
+	events.ActionStart: contracts.ActionStart,
+	events.ActionStop:  contracts.ActionStop,
+	events.ActionDie:   contracts.ActionDie,
+	events.ActionOOM:   contracts.ActionOOM,
+}
+
+// Inventory список целей запущенных контейнеров, который обновляется по событиям Docker
+// (запуск и остановка контейнеров, подключение к сетям, смена HEALTHCHECK) и полностью
+// пересобирается раз в resync. ID новых и измененных контейнеров отправляются в Updates,
+// события жизненного цикла - в Events, ID удаленных сетей - в RemovedNetworks, цели упавших и
+// остановленных контейнеров - в Stopped. Цели остановленных
+// контейнеров хранятся в stopped до удаления контейнера, чтобы события после остановки можно было
+// привязать к целям
+type Inventory struct {
+	cli     dockerClient
+	log     *slog.Logger
+	resync  time.Duration
+	targets map[string][]Target
+	stopped map[string][]Target
+	updates chan string
+	events  chan []contracts.ContainerEvent
+	removed chan string
+	down    chan []Target
+	mu      sync.RWMutex
+}
+
+func NewInventory(cli dockerClient, log *slog.Logger, resync time.Duration) *Inventory {
+	return &Inventory{
+		cli:     cli,
+		log:     log,
+		resync:  resync,
+		targets: map[string][]Target{},
+		stopped: map[string][]Target{},
+		updates: make(chan string, 64),
+		events:  make(chan []contracts.ContainerEvent, 64),
+		removed: make(chan string, 64),
+		down:    make(chan []Target, 64),
+	}
+}
+
+// Run выполняет полную синхронизацию и обрабатывает события Docker до отмены ctx
+func (i *Inventory) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.resync)
+	defer ticker.Stop()
+
+	for {
+		i.Sync(ctx)
+
+		msgs, errs := i.cli.Events(ctx, events.ListOptions{Filters: filters.NewArgs(
+			filters.Arg("type", string(events.ContainerEventType)),
+			filters.Arg("type", string(events.NetworkEventType)),
+		)})
+
+		if !i.watch(ctx, ticker.C, msgs, errs) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// watch обрабатывает события до ошибки подписки (возвращает true) или отмены ctx (возвращает false)
+func (i *Inventory) watch(ctx context.Context, resync <-chan time.Time, msgs <-chan events.Message, errs <-chan error) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-resync:
+			i.Sync(ctx)
+		case msg := <-msgs:
+			i.Handle(ctx, msg)
+		case err := <-errs:
+			i.log.Error("docker events stream failed", slog.Any("error", err))
+			return true
+		}
+	}
+}
+
+// Sync пересобирает список целей по всем запущенным контейнерам. Контейнеры, остановка которых
+// была пропущена, переносятся в stopped, а о целях удаленных контейнеров и пропавших сетей
+// отправляются отметки об удалении
+func (i *Inventory) Sync(ctx context.Context) {
+	containers, err := i.cli.ContainerList(ctx, containertypes.ListOptions{All: true})
+	if err != nil {
+		i.log.Error("failed to get container list", slog.Any("error", err))
+		return
+	}
+
+	exists := make(map[string]struct{}, len(containers))
+	targets := make(map[string][]Target, len(containers))
+	for _, c := range containers {
+		exists[c.ID] = struct{}{}
+		if c.State != "running" {
+			continue
+		}
+
+		inspect, err := i.cli.ContainerInspect(ctx, c.ID)
+		if err != nil {
+			i.log.Error("failed to inspect container", slog.String("ID", c.ID), slog.Any("error", err))
+			continue
+		}
+
+		targets[c.ID] = newTargets(inspect)
+	}
+
+	i.mu.Lock()
+	for id, known := range i.targets {
+		if current, ok := targets[id]; ok {
+			i.prune(known, current)
+		} else {
+			i.stopped[id] = known
+		}
+	}
+	for id := range targets {
+		if _, ok := i.targets[id]; !ok {
+			i.notify(id)
+		}
+	}
+	for id, known := range i.stopped {
+		_, running := targets[id]
+		_, ok := exists[id]
+		if !ok {
+			i.prune(known, nil)
+		}
+		if !ok || running {
+			delete(i.stopped, id)
+		}
+	}
+	i.targets = targets
+	i.mu.Unlock()
+
+	i.log.Debug("inventory synced", slog.Int("containers", len(targets)))
+}
+
+// Handle обновляет список целей по событию Docker msg и сообщает о событиях жизненного цикла.
+// О целях, которые пропали после события (удаление контейнера, отключение от сети, смена имени),
+// отправляются отметки об удалении
+func (i *Inventory) Handle(ctx context.Context, msg events.Message) {
+	if msg.Type == events.NetworkEventType && msg.Action == events.ActionDestroy {
+		select {
+		case i.removed <- msg.Actor.ID:
+		default:
+		}
+		return
+	}
+
+	var id string
+	switch msg.Type {
+	case events.ContainerEventType:
+		id = msg.Actor.ID
+	case events.NetworkEventType:
+		id = msg.Actor.Attributes["container"]
+	}
+	if id == "" {
+		return
+	}
+
+	previous := i.known(id)
+
+	switch {
+	case msg.Action == events.ActionStart, msg.Action == events.ActionUnPause, msg.Action == events.ActionRename,
+		msg.Action == events.ActionConnect, msg.Action == events.ActionDisconnect,
+		strings.HasPrefix(string(msg.Action), string(events.ActionHealthStatus)):
+		i.update(ctx, id)
+	case msg.Action == events.ActionDie, msg.Action == events.ActionStop:
+		// контейнер больше не проверяется, поэтому о его недоступности сообщается один раз сразу
+		if targets := i.remove(id); len(targets) > 0 {
+			i.stop(targets)
+		}
+	case msg.Action == events.ActionPause:
+		i.remove(id)
+	case msg.Action == events.ActionDestroy:
+		i.remove(id)
+		i.forget(id)
+	}
+
+	i.mu.Lock()
+	i.prune(previous, i.knownLocked(id))
+	i.mu.Unlock()
+
+	if action, ok := lifecycleActions[msg.Action]; ok && msg.Type == events.ContainerEventType {
+		i.report(ctx, id, action, msg)
+	}
+}
+
+// update перечитывает параметры контейнера id, остановленный контейнер удаляется из списка
+func (i *Inventory) update(ctx context.Context, id string) {
+	inspect, err := i.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		i.log.Error("failed to inspect container", slog.String("ID", id), slog.Any("error", err))
+		return
+	}
+
+	if inspect.ContainerJSONBase == nil || inspect.State == nil || !inspect.State.Running || inspect.State.Paused {
+		i.remove(id)
+		return
+	}
+
+	i.mu.Lock()
+	i.targets[id] = newTargets(inspect)
+	i.notify(id)
+	i.mu.Unlock()
+}
+
+// remove убирает контейнер id из списка целей, его цели сохраняются в stopped.
+// Возвращает цели, если контейнер до этого проверялся
+func (i *Inventory) remove(id string) []Target {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	targets, ok := i.targets[id]
+	if ok {
+		i.stopped[id] = targets
+	}
+	delete(i.targets, id)
+
+	return targets
+}
+
+// stop отправляет цели остановленного контейнера, не блокируясь
+func (i *Inventory) stop(targets []Target) {
+	select {
+	case i.down <- targets:
+	default:
+		i.log.Warn("stopped targets dropped", slog.String("key", targets[0].Key))
+	}
+}
+
+// forget удаляет сохраненные цели удаленного контейнера id
+func (i *Inventory) forget(id string) {
+	i.mu.Lock()
+	delete(i.stopped, id)
+	i.mu.Unlock()
+}
+
+// known возвращает цели контейнера id, в том числе остановленного
+func (i *Inventory) known(id string) []Target {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.knownLocked(id)
+}
+
+func (i *Inventory) knownLocked(id string) []Target {
+	if targets, ok := i.targets[id]; ok {
+		return targets
+	}
+
+	return i.stopped[id]
+}
+
+// prune отправляет отметки об удалении целей из previous, которых нет в current. Вызывается под i.mu
+func (i *Inventory) prune(previous, current []Target) {
+	var removed []Target
+	for _, t := range previous {
+		if !slices.ContainsFunc(current, func(c Target) bool { return c.Key == t.Key }) {
+			removed = append(removed, t)
+		}
+	}
+
+	if len(removed) > 0 {
+		i.send(Tombstones(removed, time.Now()))
+	}
+}
+
+// report отправляет событие action контейнера id для каждой его цели. Для остановившегося
+// контейнера код выхода и признак OOM берутся из inspect, а при ошибке - из атрибутов события
+func (i *Inventory) report(ctx context.Context, id, action string, msg events.Message) {
+	targets := i.known(id)
+
+	if len(targets) == 0 {
+		i.log.Debug("skip event of unknown container", slog.String("ID", id), slog.String("action", action))
+		return
+	}
+
+	at := time.Now()
+	if msg.TimeNano > 0 {
+		at = time.Unix(0, msg.TimeNano)
+	}
+	event := contracts.ContainerEvent{Action: action, Time: at.Format(time.DateTime)}
+
+	if action == contracts.ActionDie {
+		inspect, err := i.cli.ContainerInspect(ctx, id)
+		if err == nil && inspect.ContainerJSONBase != nil && inspect.State != nil {
+			event.ExitCode = inspect.State.ExitCode
+			event.OOMKilled = inspect.State.OOMKilled
+		} else {
+			event.ExitCode, _ = strconv.Atoi(msg.Actor.Attributes["exitCode"])
+		}
+	}
+
+	containerEvents := make([]contracts.ContainerEvent, len(targets))
+	for idx, t := range targets {
+		containerEvents[idx] = event
+		containerEvents[idx].ContainerInfo = t.ContainerInfo
+	}
+
+	i.send(containerEvents)
+}
+
+// send отправляет события, не блокируясь
+func (i *Inventory) send(containerEvents []contracts.ContainerEvent) {
+	select {
+	case i.events <- containerEvents:
+	default:
+		i.log.Warn("container events dropped", slog.String("key", containerEvents[0].Key),
+			slog.String("action", containerEvents[0].Action))
+	}
+}
+
+// Tombstones возвращает отметки об удалении целей targets на момент at
+func Tombstones(targets []Target, at time.Time) []contracts.ContainerEvent {
+	tombstones := make([]contracts.ContainerEvent, len(targets))
+	for idx, t := range targets {
+		tombstones[idx] = contracts.ContainerEvent{
+			Action:        contracts.ActionRemoved,
+			Time:          at.Format(time.DateTime),
+			ContainerInfo: t.ContainerInfo,
+		}
+	}
+
+	return tombstones
+}
+
+// notify сообщает об измененном контейнере, не блокируясь: если получатель не успевает,
+// контейнер будет проверен на следующем тике
+func (i *Inventory) notify(id string) {
+	select {
+	case i.updates <- id:
+	default:
+	}
+}
+
+// Targets возвращает цели всех известных контейнеров
+func (i *Inventory) Targets() []Target {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var targets []Target
+	for _, t := range i.targets {
+		targets = append(targets, t...)
+	}
+
+	return targets
+}
+
+// Updates возвращает канал с ID новых и измененных контейнеров
+func (i *Inventory) Updates() <-chan string {
+	return i.updates
+}
+
+// Events возвращает канал с событиями жизненного цикла контейнеров, по одному событию на цель
+func (i *Inventory) Events() <-chan []contracts.ContainerEvent {
+	return i.events
+}
+
+// Stopped возвращает канал с целями упавших и остановленных контейнеров
+func (i *Inventory) Stopped() <-chan []Target {
+	return i.down
+}
+
+// RemovedNetworks возвращает канал с ID удаленных сетей
+func (i *Inventory) RemovedNetworks() <-chan string {
+	return i.removed
+}
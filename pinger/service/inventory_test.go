@@ -0,0 +1,248 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"errors"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type fakeDocker struct {
+	containers map[string]types.ContainerJSON
+	inspects   int
+}
+
+func (f *fakeDocker) ContainerList(ctx context.Context, options containertypes.ListOptions) ([]types.Container, error) {
+	var list []types.Container
+	for id, c := range f.containers {
+		state := "exited"
+		if c.State.Running {
+			state = "running"
+		}
+		if c.State.Running || options.All {
+			list = append(list, types.Container{ID: id, State: state})
+		}
+	}
+
+	return list, nil
+}
+
+func (f *fakeDocker) ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+	f.inspects++
+	c, ok := f.containers[containerID]
+	if !ok {
+		return types.ContainerJSON{}, errors.New("no such container")
+	}
+
+	return c, nil
+}
+
+func (f *fakeDocker) Events(ctx context.Context, options events.ListOptions) (<-chan events.Message, <-chan error) {
+	return nil, nil
+}
+
+func newFakeContainer(id, name, IP string, running bool) types.ContainerJSON {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: id, Name: "/" + name, State: &types.ContainerState{Running: running}},
+		Config:            &containertypes.Config{},
+		NetworkSettings: &types.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+			"bridge": {NetworkID: "net1", IPAddress: IP},
+		}},
+	}
+}
+
+func TestInventory(t *testing.T) {
+	docker := &fakeDocker{containers: map[string]types.ContainerJSON{
+		"a": newFakeContainer("a", "backend", "172.10.0.2", true),
+		"b": newFakeContainer("b", "stopped", "172.10.0.3", false),
+	}}
+
+	inventory := NewInventory(docker, slog.Default(), time.Minute)
+	ctx := context.Background()
+
+	inventory.Sync(ctx)
+	require.Equal(t, []string{"172.10.0.2"}, targetIPs(inventory.Targets()))
+	require.Equal(t, "a", <-inventory.Updates())
+
+	// запуск контейнера подхватывается без полной синхронизации
+	docker.containers["b"] = newFakeContainer("b", "stopped", "172.10.0.3", true)
+	inspects := docker.inspects
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionStart,
+		Actor: events.Actor{ID: "b"}})
+	require.Equal(t, inspects+1, docker.inspects)
+	require.ElementsMatch(t, []string{"172.10.0.2", "172.10.0.3"}, targetIPs(inventory.Targets()))
+	require.Equal(t, "b", <-inventory.Updates())
+
+	// смена IP при переподключении к сети
+	docker.containers["a"] = newFakeContainer("a", "backend", "172.10.0.9", true)
+	inventory.Handle(ctx, events.Message{Type: events.NetworkEventType, Action: events.ActionConnect,
+		Actor: events.Actor{ID: "net1", Attributes: map[string]string{"container": "a"}}})
+	require.ElementsMatch(t, []string{"172.10.0.9", "172.10.0.3"}, targetIPs(inventory.Targets()))
+
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionDie,
+		Actor: events.Actor{ID: "b"}})
+	require.Equal(t, []string{"172.10.0.9"}, targetIPs(inventory.Targets()))
+
+	// события без контейнера и неинтересные действия игнорируются
+	inspects = docker.inspects
+	inventory.Handle(ctx, events.Message{Type: events.NetworkEventType, Action: events.ActionCreate,
+		Actor: events.Actor{ID: "net2"}})
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionExecStart,
+		Actor: events.Actor{ID: "a"}})
+	require.Equal(t, inspects, docker.inspects)
+
+	// удаление сети
+	inventory.Handle(ctx, events.Message{Type: events.NetworkEventType, Action: events.ActionDestroy,
+		Actor: events.Actor{ID: "net2"}})
+	require.Equal(t, "net2", <-inventory.RemovedNetworks())
+}
+
+func TestInventory_Events(t *testing.T) {
+	docker := &fakeDocker{containers: map[string]types.ContainerJSON{
+		"a": newFakeContainer("a", "backend", "172.10.0.2", true),
+	}}
+
+	inventory := NewInventory(docker, slog.Default(), time.Minute)
+	ctx := context.Background()
+	inventory.Sync(ctx)
+
+	// падение по OOM: сначала oom, затем die с кодом выхода из inspect
+	died := newFakeContainer("a", "backend", "", false)
+	died.State.ExitCode = 137
+	died.State.OOMKilled = true
+	docker.containers["a"] = died
+
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local)
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionOOM,
+		Actor: events.Actor{ID: "a"}, TimeNano: at.UnixNano()})
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionDie,
+		Actor: events.Actor{ID: "a", Attributes: map[string]string{"exitCode": "137"}}, TimeNano: at.UnixNano()})
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionStop,
+		Actor: events.Actor{ID: "a"}, TimeNano: at.UnixNano()})
+
+	oom := <-inventory.Events()
+	require.Len(t, oom, 1)
+	require.Equal(t, contracts.ActionOOM, oom[0].Action)
+	require.Equal(t, "backend@bridge", oom[0].Key)
+	require.Equal(t, "2025-01-01 12:00:00", oom[0].Time)
+
+	die := <-inventory.Events()
+	require.Len(t, die, 1)
+	require.Equal(t, contracts.ActionDie, die[0].Action)
+	require.Equal(t, 137, die[0].ExitCode)
+	require.True(t, die[0].OOMKilled)
+
+	// цели остановленного контейнера сохраняются для событий после остановки
+	stop := <-inventory.Events()
+	require.Len(t, stop, 1)
+	require.Equal(t, "backend@bridge", stop[0].Key)
+
+	// при удалении контейнера отправляется отметка об удалении, после нее события не отправляются
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionDestroy,
+		Actor: events.Actor{ID: "a"}})
+	removed := <-inventory.Events()
+	require.Len(t, removed, 1)
+	require.Equal(t, contracts.ActionRemoved, removed[0].Action)
+	require.Equal(t, "backend@bridge", removed[0].Key)
+
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionStop,
+		Actor: events.Actor{ID: "a"}})
+	require.Empty(t, inventory.Events())
+
+	docker.containers["a"] = newFakeContainer("a", "backend", "172.10.0.4", true)
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionStart,
+		Actor: events.Actor{ID: "a"}})
+	start := <-inventory.Events()
+	require.Equal(t, contracts.ActionStart, start[0].Action)
+	require.Equal(t, 0, start[0].ExitCode)
+}
+
+func TestInventory_Tombstones(t *testing.T) {
+	twoNetworks := newFakeContainer("a", "backend", "172.10.0.2", true)
+	twoNetworks.NetworkSettings.Networks["db"] = &network.EndpointSettings{NetworkID: "net2", IPAddress: "172.20.0.2"}
+
+	docker := &fakeDocker{containers: map[string]types.ContainerJSON{
+		"a": twoNetworks,
+		"b": newFakeContainer("b", "worker", "172.10.0.3", true),
+	}}
+
+	inventory := NewInventory(docker, slog.Default(), time.Minute)
+	ctx := context.Background()
+	inventory.Sync(ctx)
+
+	// отключение от сети
+	docker.containers["a"] = newFakeContainer("a", "backend", "172.10.0.2", true)
+	inventory.Handle(ctx, events.Message{Type: events.NetworkEventType, Action: events.ActionDisconnect,
+		Actor: events.Actor{ID: "net2", Attributes: map[string]string{"container": "a"}}})
+	removed := <-inventory.Events()
+	require.Equal(t, []string{"backend@db"}, eventKeys(t, removed))
+
+	// остановка, пропущенная в событиях, не считается удалением
+	docker.containers["b"] = newFakeContainer("b", "worker", "", false)
+	inventory.Sync(ctx)
+	require.Empty(t, inventory.Events())
+	require.Equal(t, []string{"172.10.0.2"}, targetIPs(inventory.Targets()))
+
+	// удаление, пропущенное в событиях
+	delete(docker.containers, "b")
+	inventory.Sync(ctx)
+	removed = <-inventory.Events()
+	require.Equal(t, []string{"worker@bridge"}, eventKeys(t, removed))
+	require.Empty(t, inventory.Events())
+}
+
+func eventKeys(t *testing.T, containerEvents []contracts.ContainerEvent) []string {
+	var keys []string
+	for _, e := range containerEvents {
+		require.Equal(t, contracts.ActionRemoved, e.Action)
+		keys = append(keys, e.Key)
+	}
+
+	return keys
+}
+
+func targetIPs(targets []Target) []string {
+	var ips []string
+	for _, t := range targets {
+		ips = append(ips, t.IP)
+	}
+
+	return ips
+}
+
+func TestInventory_Stopped(t *testing.T) {
+	docker := &fakeDocker{containers: map[string]types.ContainerJSON{
+		"a": newFakeContainer("a", "backend", "172.10.0.2", true),
+	}}
+
+	inventory := NewInventory(docker, slog.Default(), time.Minute)
+	ctx := context.Background()
+	inventory.Sync(ctx)
+
+	// падение контейнера: его цели отправляются один раз, хотя за die следует stop
+	docker.containers["a"] = newFakeContainer("a", "backend", "", false)
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionDie,
+		Actor: events.Actor{ID: "a"}})
+	inventory.Handle(ctx, events.Message{Type: events.ContainerEventType, Action: events.ActionStop,
+		Actor: events.Actor{ID: "a"}})
+
+	require.Len(t, inventory.Stopped(), 1)
+	stopped := <-inventory.Stopped()
+	require.Equal(t, []string{"172.10.0.2"}, targetIPs(stopped))
+	require.Empty(t, inventory.Targets())
+
+	// последний результат остановленного контейнера - недоступен
+	at := time.Date(2025, 2, 8, 10, 0, 0, 0, time.UTC)
+	data := StoppedPingData(stopped[0], at)
+	require.False(t, data.IsReachable)
+	require.Equal(t, "172.10.0.2", data.IPAddress)
+	require.Equal(t, "2025-02-08 10:00:00", data.LastPing)
+	require.Equal(t, "backend@bridge", data.Key)
+}
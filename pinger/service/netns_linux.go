@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/sys/unix"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// NetnsAccess доступ через сетевое пространство имен контейнера: проверки выполняются
+// в потоке ОС, переключенном в /proc/<PID>/ns/net цели. Подключения проверок сервиса
+// выполняются через Target.Dial, так как HTTP, gRPC и DNS-клиенты подключаются из других горутин
+type NetnsAccess struct {
+	proc string
+}
+
+// check for implementation
+var _ NetAccess = (*NetnsAccess)(nil)
+
+// NewNetnsAccess создает доступ через пространства имен, proc - путь к /proc хоста
+func NewNetnsAccess(proc string) *NetnsAccess {
+	return &NetnsAccess{proc: proc}
+}
+
+func (a *NetnsAccess) Prepare(t Target) (Target, error) {
+	path, err := a.path(t)
+	if err != nil {
+		return t, err
+	}
+
+	t.Dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+		var conn net.Conn
+		err := inNetns(path, func() error {
+			var d net.Dialer
+			var err error
+			conn, err = d.DialContext(ctx, network, address)
+			return err
+		})
+
+		return conn, err
+	}
+
+	return t, nil
+}
+
+func (a *NetnsAccess) Run(t Target, fn func() error) error {
+	path, err := a.path(t)
+	if err != nil {
+		return err
+	}
+
+	return inNetns(path, fn)
+}
+
+func (a *NetnsAccess) Release(active []string, now time.Time) []string {
+	return nil
+}
+
+func (a *NetnsAccess) Forget(net string) {}
+
+// ICMPReliable всегда false: внутри пространства имен контейнер отвечает сам себе,
+// даже если из его сетей он недоступен
+func (a *NetnsAccess) ICMPReliable() bool {
+	return false
+}
+
+// path возвращает путь к сетевому пространству имен контейнера цели t
+func (a *NetnsAccess) path(t Target) (string, error) {
+	if t.PID <= 0 {
+		return "", errors.New("container PID is unknown")
+	}
+
+	return filepath.Join(a.proc, strconv.Itoa(t.PID), "ns", "net"), nil
+}
+
+// inNetns выполняет fn в сетевом пространстве имен path. fn выполняется в отдельной горутине
+// с закрепленным потоком ОС: если вернуть поток в исходное пространство не удалось,
+// он не открепляется и завершается вместе с горутиной
+func inNetns(path string, fn func() error) error {
+	done := make(chan error, 1)
+
+	go func() {
+		runtime.LockOSThread()
+
+		origin, err := os.Open("/proc/thread-self/ns/net")
+		if err != nil {
+			runtime.UnlockOSThread()
+			done <- fmt.Errorf("failed to open current netns: %w", err)
+			return
+		}
+		defer origin.Close()
+
+		target, err := os.Open(path)
+		if err != nil {
+			runtime.UnlockOSThread()
+			done <- fmt.Errorf("failed to open netns: %w", err)
+			return
+		}
+		defer target.Close()
+
+		if err = unix.Setns(int(target.Fd()), unix.CLONE_NEWNET); err != nil {
+			runtime.UnlockOSThread()
+			done <- fmt.Errorf("failed to enter netns: %w", err)
+			return
+		}
+
+		err = fn()
+
+		if unix.Setns(int(origin.Fd()), unix.CLONE_NEWNET) == nil {
+			runtime.UnlockOSThread()
+		}
+		done <- err
+	}()
+
+	return <-done
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNetnsAccess(t *testing.T) {
+	access := NewNetnsAccess("/proc")
+
+	// без PID войти в пространство имен контейнера нельзя
+	_, err := access.Prepare(Target{IP: "127.0.0.1"})
+	require.Error(t, err)
+
+	// вход в собственное пространство имен требует CAP_SYS_ADMIN
+	err = inNetns("/proc/self/ns/net", func() error { return nil })
+	if errors.Is(err, os.ErrPermission) {
+		t.Skip("CAP_SYS_ADMIN is required")
+	}
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	target, err := access.Prepare(Target{IP: "127.0.0.1", PID: os.Getpid()})
+	require.NoError(t, err)
+
+	result := probeTCP(context.Background(), target, listener.Addr().(*net.TCPAddr).Port, time.Second)
+	require.True(t, result.Success, result.Error)
+
+	require.Error(t, inNetns("/proc/0/ns/net", func() error { return nil }))
+}
@@ -0,0 +1,36 @@
+//go:build !linux
+
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+// NetnsAccess доступ через сетевое пространство имен контейнера, поддерживается только в Linux
+type NetnsAccess struct{}
+
+// check for implementation
+var _ NetAccess = (*NetnsAccess)(nil)
+
+func NewNetnsAccess(proc string) *NetnsAccess {
+	return &NetnsAccess{}
+}
+
+func (a *NetnsAccess) Prepare(t Target) (Target, error) {
+	return t, errors.New("netns mode is supported only on linux")
+}
+
+func (a *NetnsAccess) Run(t Target, fn func() error) error {
+	return errors.New("netns mode is supported only on linux")
+}
+
+func (a *NetnsAccess) Release(active []string, now time.Time) []string {
+	return nil
+}
+
+func (a *NetnsAccess) Forget(net string) {}
+
+func (a *NetnsAccess) ICMPReliable() bool {
+	return false
+}
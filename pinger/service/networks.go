@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// networkClient методы Docker SDK, которые использует Networks
+type networkClient interface {
+	NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error
+	NetworkDisconnect(ctx context.Context, networkID, containerID string, force bool) error
+}
+
+// Networks сети, к которым подключен контейнер pinger'а id. Сети static (заданные при запуске pinger'а)
+// не отключаются никогда, к остальным pinger подключается по мере необходимости и отключается от них,
+// если в сети не осталось проверяемых контейнеров дольше idle. Для подключенных динамически сетей
+// хранится время, когда в них последний раз были цели
+type Networks struct {
+	cli    networkClient
+	log    *slog.Logger
+	id     string
+	idle   time.Duration
+	static map[string]struct{}
+	joined map[string]time.Time
+	mu     sync.Mutex
+}
+
+// check for implementation
+var _ NetAccess = (*Networks)(nil)
+
+func NewNetworks(cli networkClient, log *slog.Logger, id string, idle time.Duration) *Networks {
+	return &Networks{
+		cli:    cli,
+		log:    log,
+		id:     id,
+		idle:   idle,
+		static: map[string]struct{}{},
+		joined: map[string]time.Time{},
+	}
+}
+
+// Add учитывает сеть net, к которой pinger уже подключен: static - сеть задана при запуске
+// и не отключается, иначе сеть считается подключенной динамически в момент now
+func (n *Networks) Add(net string, static bool, now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if static {
+		n.static[net] = struct{}{}
+		return
+	}
+	n.joined[net] = now
+}
+
+// Connect подключает pinger к сети net, если он еще не подключен
+func (n *Networks) Connect(net string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.static[net]; ok {
+		return nil
+	}
+	if _, ok := n.joined[net]; ok {
+		return nil
+	}
+
+	err := n.cli.NetworkConnect(context.Background(), net, n.id, &network.EndpointSettings{})
+	if err != nil {
+		return fmt.Errorf("failed to connect network: %w", err)
+	}
+
+	n.joined[net] = time.Now()
+	n.log.Info("network joined", slog.String("network", net))
+
+	return nil
+}
+
+// Prepare подключает pinger к сети цели t
+func (n *Networks) Prepare(t Target) (Target, error) {
+	return t, n.Connect(t.NetworkID)
+}
+
+// Run выполняет fn в сети pinger'а, подключенной к сети цели в Prepare
+func (n *Networks) Run(t Target, fn func() error) error {
+	return fn()
+}
+
+// Release отключает pinger от динамически подключенных сетей, в которых нет целей active
+// дольше idle. Если отключиться не удалось, следующая попытка выполняется через idle.
+// Возвращает отключенные сети
+func (n *Networks) Release(active []string, now time.Time) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, net := range active {
+		if _, ok := n.joined[net]; ok {
+			n.joined[net] = now
+		}
+	}
+
+	var released []string
+	for net, used := range n.joined {
+		if now.Sub(used) < n.idle {
+			continue
+		}
+
+		err := n.cli.NetworkDisconnect(context.Background(), net, n.id, false)
+		if err != nil && !errdefs.IsNotFound(err) && !isNotConnected(err) {
+			n.log.Error("failed to disconnect network", slog.String("network", net), slog.Any("error", err))
+			n.joined[net] = now
+			continue
+		}
+
+		delete(n.joined, net)
+		released = append(released, net)
+		n.log.Info("network left", slog.String("network", net))
+	}
+
+	return released
+}
+
+// Forget забывает удаленную сеть net
+func (n *Networks) Forget(net string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.static, net)
+	delete(n.joined, net)
+}
+
+func (n *Networks) ICMPReliable() bool {
+	return true
+}
+
+// isNotConnected сообщает, что pinger уже не подключен к сети: Docker возвращает эту ошибку
+// без отдельного типа, если контейнер отключили вручную
+func isNotConnected(err error) bool {
+	return strings.Contains(err.Error(), "is not connected to")
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"errors"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type fakeNetworks struct {
+	connected    []string
+	disconnected []string
+	removed      map[string]bool
+	failing      map[string]error
+	attempts     int
+}
+
+func (f *fakeNetworks) NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+	f.connected = append(f.connected, networkID)
+	return nil
+}
+
+func (f *fakeNetworks) NetworkDisconnect(ctx context.Context, networkID, containerID string, force bool) error {
+	f.attempts++
+	if f.removed[networkID] {
+		return errdefs.NotFound(context.Canceled)
+	}
+	if err := f.failing[networkID]; err != nil {
+		return err
+	}
+
+	f.disconnected = append(f.disconnected, networkID)
+	return nil
+}
+
+func TestNetworks(t *testing.T) {
+	docker := &fakeNetworks{removed: map[string]bool{"net4": true}}
+	now := time.Now()
+
+	networks := NewNetworks(docker, slog.Default(), "pinger", time.Minute)
+	networks.Add("own", true, now)
+	networks.Add("net1", false, now)
+
+	// к своим и уже подключенным сетям повторно не подключаемся
+	require.NoError(t, networks.Connect("own"))
+	require.NoError(t, networks.Connect("net1"))
+	require.NoError(t, networks.Connect("net2"))
+	require.NoError(t, networks.Connect("net2"))
+	require.Equal(t, []string{"net2"}, docker.connected)
+
+	// пока в сети есть цели или не истекло время простоя, pinger от нее не отключается
+	require.Empty(t, networks.Release([]string{"net1"}, now.Add(30*time.Second)))
+	require.Equal(t, []string{"net2"}, networks.Release([]string{"own", "net1"}, now.Add(2*time.Minute)))
+	require.Equal(t, []string{"net1"}, networks.Release(nil, now.Add(4*time.Minute)))
+	require.Equal(t, []string{"net2", "net1"}, docker.disconnected)
+
+	// после отключения сеть подключается заново
+	require.NoError(t, networks.Connect("net2"))
+	require.Equal(t, []string{"net2", "net2"}, docker.connected)
+
+	// удаленная сеть забывается без отключения
+	networks.Forget("net2")
+	require.NoError(t, networks.Connect("net4"))
+	require.Equal(t, []string{"net4"}, networks.Release(nil, now.Add(time.Hour)))
+	require.Equal(t, []string{"net2", "net1"}, docker.disconnected)
+}
+
+func TestNetworks_ReleaseErrors(t *testing.T) {
+	docker := &fakeNetworks{failing: map[string]error{
+		"manual": errors.New("container pinger is not connected to network manual"),
+		"broken": errors.New("daemon is busy"),
+	}}
+	now := time.Now()
+
+	networks := NewNetworks(docker, slog.Default(), "pinger", time.Minute)
+	networks.Add("manual", false, now)
+	networks.Add("broken", false, now)
+
+	// отключенная вручную сеть считается освобожденной, ошибка откладывает повтор на время простоя
+	require.Equal(t, []string{"manual"}, networks.Release(nil, now.Add(2*time.Minute)))
+	require.Equal(t, 2, docker.attempts)
+
+	require.Empty(t, networks.Release(nil, now.Add(2*time.Minute+time.Second)))
+	require.Equal(t, 2, docker.attempts)
+
+	delete(docker.failing, "broken")
+	require.Equal(t, []string{"broken"}, networks.Release(nil, now.Add(4*time.Minute)))
+	require.Equal(t, 3, docker.attempts)
+}
+
+func TestGoPinger_ReleaseNetworks(t *testing.T) {
+	docker := &fakeNetworks{}
+	networks := NewNetworks(docker, slog.Default(), "pinger", time.Minute)
+	networks.Add("net2", false, time.Now())
+
+	pinger := &GoPinger{access: networks, id: "pinger"}
+	self := Target{NetworkID: "net2", ContainerInfo: contracts.ContainerInfo{ContainerID: "pinger"}}
+
+	// в сети осталась только конечная точка самого pinger'а - сеть освобождается после простоя
+	pinger.ReleaseNetworks(map[string][]Target{"net2": {self}})
+	require.Empty(t, docker.disconnected)
+
+	networks.Add("net2", false, time.Now().Add(-2*time.Minute))
+	pinger.ReleaseNetworks(map[string][]Target{"net2": {self}})
+	require.Equal(t, []string{"net2"}, docker.disconnected)
+}
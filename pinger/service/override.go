@@ -0,0 +1,83 @@
+package service
+
+import (
+	"app-pinger/pinger/config"
+	"maps"
+	"strings"
+)
+
+// ApplyRuntime применяет к цели t действующие настройки cfg: общие параметры пинга (интервал и таймаут,
+// если они не заданы метками контейнера) и настройки подходящих по имени или ключу целей из cfg.Targets
+// (при нескольких совпадениях побеждает последнее). Метки из настроек дополняют метки контейнера,
+// затронутые ими проверки сервиса пересчитываются
+func ApplyRuntime(t Target, cfg *config.Runtime) Target {
+	t.PacketsCount = cfg.PacketsCount
+	if t.PingTimeout == 0 {
+		t.PingTimeout = cfg.PingTimeout
+	}
+	if t.Interval == 0 {
+		t.Interval = cfg.Interval
+	}
+
+	for _, o := range cfg.Targets {
+		if !matchGlob([]string{o.Match}, t.Name) && !matchGlob([]string{o.Match}, t.Key) {
+			continue
+		}
+
+		if o.PacketsCount > 0 {
+			t.PacketsCount = o.PacketsCount
+		}
+		if o.PingTimeout > 0 {
+			t.PingTimeout = o.PingTimeout
+		}
+		if o.Interval > 0 {
+			t.Interval = o.Interval
+		}
+		if len(o.Labels) > 0 {
+			t = withLabels(t, o.Labels)
+		}
+	}
+
+	return t
+}
+
+// withLabels добавляет к меткам цели labels и пересчитывает проверки, настройки которых затронуты
+func withLabels(t Target, labels map[string]string) Target {
+	merged := maps.Clone(t.Labels)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	maps.Copy(merged, labels)
+	t.Labels = merged
+
+	if d := parseDuration(labels[intervalLabel]); d > 0 {
+		t.Interval = d
+	}
+	if d := parseDuration(labels[timeoutLabel]); d > 0 {
+		t.PingTimeout = d
+	}
+	if value, ok := labels[tcpPortsLabel]; ok {
+		t.TCPPorts = parsePorts(value)
+	}
+	if _, ok := labels[dnsNamesLabel]; ok {
+		t.DNSNames = dnsNames(merged)
+	}
+	if hasLabelPrefix(labels, "pinger.http.") {
+		t.HTTP = newHTTPCheck(merged, t.TCPPorts)
+	}
+	if hasLabelPrefix(labels, "pinger.grpc.") {
+		t.GRPC = newGRPCCheck(merged)
+	}
+
+	return t
+}
+
+func hasLabelPrefix(labels map[string]string, prefix string) bool {
+	for label := range labels {
+		if strings.HasPrefix(label, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
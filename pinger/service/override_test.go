@@ -0,0 +1,61 @@
+package service
+
+import (
+	"app-pinger/pinger/config"
+	"app-pinger/pkg/contracts"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestApplyRuntime(t *testing.T) {
+	target := Target{
+		IP:       "172.10.0.2",
+		TCPPorts: []int{8080},
+		DNSNames: []string{"web"},
+		ContainerInfo: contracts.ContainerInfo{
+			Key:    "app.web.1@app_default",
+			Name:   "app-web-1",
+			Labels: map[string]string{composeServiceLabel: "web"},
+		},
+	}
+
+	cfg := &config.Runtime{
+		Interval:     15 * time.Second,
+		PacketsCount: 4,
+		PingTimeout:  5 * time.Second,
+		Targets: []config.TargetOverride{
+			{Match: "app-*", PacketsCount: 2},
+			{Match: "app.web.*@*", PingTimeout: time.Second, Labels: map[string]string{
+				httpPathLabel: "/healthz",
+				tcpPortsLabel: "8080,9090",
+				dnsNamesLabel: "web.internal",
+				grpcPortLabel: "50051",
+			}},
+			{Match: "db-*", PacketsCount: 10},
+			{Match: "app-web-1", Labels: map[string]string{intervalLabel: "2s"}},
+		},
+	}
+
+	got := ApplyRuntime(target, cfg)
+
+	require.Equal(t, 2, got.PacketsCount)
+	require.Equal(t, time.Second, got.PingTimeout)
+	require.Equal(t, 2*time.Second, got.Interval)
+	require.Equal(t, []int{8080, 9090}, got.TCPPorts)
+	require.Equal(t, []string{"web.internal"}, got.DNSNames)
+	require.NotNil(t, got.HTTP)
+	require.Equal(t, "/healthz", got.HTTP.Path)
+	require.Equal(t, &GRPCCheck{Port: 50051}, got.GRPC)
+	require.Equal(t, "web", got.Labels[composeServiceLabel])
+
+	// метки исходной цели не изменяются
+	require.Len(t, target.Labels, 1)
+
+	// интервал из метки контейнера важнее общего
+	other := ApplyRuntime(Target{Interval: time.Minute, ContainerInfo: contracts.ContainerInfo{Name: "cache"}}, cfg)
+	require.Equal(t, 4, other.PacketsCount)
+	require.Equal(t, 5*time.Second, other.PingTimeout)
+	require.Equal(t, time.Minute, other.Interval)
+	require.Nil(t, other.HTTP)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"app-pinger/pinger/config"
 	"app-pinger/pkg/contracts"
 	queue "app-pinger/pkg/queue"
 	"context"
@@ -8,20 +9,26 @@ import (
 	"fmt"
 	"github.com/docker/docker/api/types"
 	containertypes "github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/go-ping/ping"
 	"log/slog"
+	"slices"
 	"strings"
-	"sync"
 	"time"
 )
 
 // Pinger интерфейс, который определяет логику сервиса
 type Pinger interface {
-	GetIPs(list []string, whiteList bool) map[string][]string
-	Ping(net, IP string) contracts.PingData
+	Watch(ctx context.Context)
+	Updates() <-chan string
+	GetTargets(f *Filter) map[string][]Target
+	Ping(ctx context.Context, t Target) contracts.PingData
+	Probe(ctx context.Context, t Target) []contracts.ProbeResult
 	SendRequest(data []contracts.PingData) error
+	Events() <-chan []contracts.ContainerEvent
+	Stopped() <-chan []Target
+	SendEvents(events []contracts.ContainerEvent) error
+	ReleaseNetworks(targets map[string][]Target)
 }
 
 // PingerSvc сервис, который выполняет бизнес логику сервиса
@@ -35,14 +42,29 @@ func NewPingerService(pinger Pinger) *PingerSvc {
 	}
 }
 
-// GetIPs получает все доступные IP-адреса контейнеров
-func (p *PingerSvc) GetIPs(list []string, whiteList bool) map[string][]string {
-	return p.Pinger.GetIPs(list, whiteList)
+// Watch отслеживает запуск и остановку контейнеров до отмены ctx
+func (p *PingerSvc) Watch(ctx context.Context) {
+	p.Pinger.Watch(ctx)
 }
 
-// Ping пингует IP адрес, возвращает данные доступности
-func (p *PingerSvc) Ping(net, IP string) contracts.PingData {
-	return p.Pinger.Ping(net, IP)
+// Updates возвращает канал с ID новых и измененных контейнеров, которые нужно проверить сразу
+func (p *PingerSvc) Updates() <-chan string {
+	return p.Pinger.Updates()
+}
+
+// GetTargets получает все доступные цели пинга (адреса контейнеров в их сетях), отобранные фильтром f
+func (p *PingerSvc) GetTargets(f *Filter) map[string][]Target {
+	return p.Pinger.GetTargets(f)
+}
+
+// Ping пингует цель, возвращает данные доступности. Отмена ctx прерывает проверку
+func (p *PingerSvc) Ping(ctx context.Context, t Target) contracts.PingData {
+	return p.Pinger.Ping(ctx, t)
+}
+
+// Probe выполняет все настроенные для цели проверки сервиса (TCP, HTTP и т.д.)
+func (p *PingerSvc) Probe(ctx context.Context, t Target) []contracts.ProbeResult {
+	return p.Pinger.Probe(ctx, t)
 }
 
 // SendRequest отправляет запрос к backend-svc через RabbitMQ с данными ping всех контейнеров
@@ -50,17 +72,43 @@ func (p *PingerSvc) SendRequest(data []contracts.PingData) error {
 	return p.Pinger.SendRequest(data)
 }
 
-// GoPinger реализация PingerSvc, основанная на Docker SDK и go-ping
+// Events возвращает канал с событиями жизненного цикла контейнеров (запуск, остановка, падение, OOM)
+func (p *PingerSvc) Events() <-chan []contracts.ContainerEvent {
+	return p.Pinger.Events()
+}
+
+// Stopped возвращает канал с целями упавших и остановленных контейнеров, которые больше не проверяются
+func (p *PingerSvc) Stopped() <-chan []Target {
+	return p.Pinger.Stopped()
+}
+
+// SendEvents отправляет события жизненного цикла контейнеров backend-svc через отдельную очередь RabbitMQ
+func (p *PingerSvc) SendEvents(events []contracts.ContainerEvent) error {
+	return p.Pinger.SendEvents(events)
+}
+
+// ReleaseNetworks отключает pinger от сетей, в которых давно нет целей targets
+func (p *PingerSvc) ReleaseNetworks(targets map[string][]Target) {
+	p.Pinger.ReleaseNetworks(targets)
+}
+
+// GoPinger реализация PingerSvc, основанная на Docker SDK и go-ping, сервисы контейнеров
+// дополнительно проверяются probers. Список контейнеров ведет inventory по событиям Docker,
+// доступ pinger'а к сетям контейнеров определяет access
 type GoPinger struct {
 	cli          *client.Client
+	inventory    *Inventory
 	log          slog.Logger
 	packetsCount int
 	pingTimeout  time.Duration
+	privileged   bool
+	probers      []Prober
 	rabbitMQ     queue.RabbitMQ
+	eventsMQ     queue.RabbitMQ
+	access       NetAccess
+	icmpV6       error
 	id           string
 	name         string
-	net          map[string]struct{}
-	mu           sync.Mutex
 }
 
 // check for implementation
@@ -71,90 +119,145 @@ func NewGoPingerService(
 	l *slog.Logger,
 	pC int,
 	pT time.Duration,
+	resync time.Duration,
+	a config.Access,
 	n string,
 	r queue.RabbitMQ,
+	e queue.RabbitMQ,
+	probers ...Prober,
 ) *GoPinger {
 	pinger := &GoPinger{
 		cli:          c,
+		inventory:    NewInventory(c, l, resync),
 		log:          *l,
 		packetsCount: pC,
 		pingTimeout:  pT,
+		probers:      probers,
 		rabbitMQ:     r,
+		eventsMQ:     e,
 		name:         n,
-		net:          map[string]struct{}{},
-		mu:           sync.Mutex{},
 	}
 
-	pinger.searchOwnIDAndNetwork()
+	// собственный контейнер исключается из целей в любом режиме доступа,
+	// сети pinger'а нужны только для подключения к сетям целей
+	own := pinger.searchOwnIDAndNetwork()
+
+	switch a.Mode {
+	case config.AccessHost:
+		pinger.access = HostAccess{}
+	case config.AccessNetns:
+		pinger.access = NewNetnsAccess(a.ProcPath)
+	default:
+		networks := NewNetworks(c, l, pinger.id, a.Idle)
+		for _, net := range own {
+			networks.Add(net, true, time.Now())
+		}
+		pinger.access = networks
+	}
+
+	pinger.selectICMP(a.ICMP)
+	if !pinger.access.ICMPReliable() {
+		pinger.log.Warn("icmp ping does not prove reachability in this network mode, "+
+			"containers without service probes are reported as unknown", slog.String("mode", a.Mode))
+	}
 
 	return pinger
 }
 
-// searchOwnIDAndNetwork находит и устанавливает id и сеть контейнера net по своему имени name
-func (p *GoPinger) searchOwnIDAndNetwork() {
-	containers, err := p.getContainerList()
+// selectICMP проверяет режимы ICMP-пинга на loopback и выбирает режим по настройке mode,
+// затем проверяет выбранный режим на IPv6 loopback. Если ICMP недоступен, результаты пингов
+// публикуются с ошибкой проверки
+func (p *GoPinger) selectICMP(mode string) {
+	test := TestICMP(time.Second)
+	p.log.Info("icmp self-test", slog.String("privileged", selfTestResult(test.Privileged)),
+		slog.String("unprivileged", selfTestResult(test.Unprivileged)))
+
+	privileged, err := test.Mode(mode)
+	p.privileged = privileged
 	if err != nil {
-		p.log.Error("failed to get container list", slog.Any("error", err))
+		p.log.Error("icmp ping is unavailable", slog.String("mode", mode), slog.Any("error", err))
 		return
 	}
 
-	for _, container := range containers {
-		if p.extractContainerName(container) == p.name {
-			p.id = container.ID
-			for _, net := range container.NetworkSettings.Networks {
-				p.net[net.NetworkID] = struct{}{}
-			}
-			break
-		}
+	p.log.Info("icmp mode selected", slog.String("mode", mode), slog.Bool("privileged", privileged))
+
+	p.icmpV6 = TestICMPv6(privileged, time.Second)
+	if p.icmpV6 != nil {
+		p.log.Warn("icmp ping over ipv6 is unavailable, ipv6 targets are checked only by service probes",
+			slog.Any("error", p.icmpV6))
 	}
 }
 
-// GetIPs возвращает мапу сеть-IP-адреса с учетом фильтра, а также его типом (белый/черный список)
-func (p *GoPinger) GetIPs(list []string, whiteList bool) map[string][]string {
-	p.log.Debug("starting get container list")
+// searchOwnIDAndNetwork находит и устанавливает id своего контейнера по имени name и возвращает сети,
+// к которым он подключен при запуске. Эти сети (в том числе внешние и общая с RabbitMQ) не отключаются
+func (p *GoPinger) searchOwnIDAndNetwork() []string {
+	var own []string
 
 	containers, err := p.getContainerList()
 	if err != nil {
 		p.log.Error("failed to get container list", slog.Any("error", err))
-		return nil
-	}
-	if len(containers) == 0 {
-		p.log.Error("failed to get container list", slog.Any("error", "get 0 containers"))
-		return nil
+		return own
 	}
 
-	hasFilter := len(list) > 0
-
-	p.log.Debug("successful get container list")
-
-	listFilter := make(map[string]struct{}, len(list))
-	for _, container := range list {
-		listFilter[container] = struct{}{}
-	}
-
-	ips := map[string][]string{}
 	for _, container := range containers {
-		inspect, err := p.inspectContainer(container.ID)
-		if err != nil {
-			p.log.Error("failed to inspect container", slog.String("ID", container.ID), slog.Any("error", err))
+		if p.extractContainerName(container) != p.name {
 			continue
 		}
 
-		containerIPs := p.filterNetworkIPs(inspect)
-		if len(containerIPs) == 0 {
-			continue
+		p.id = container.ID
+		if container.NetworkSettings != nil {
+			for _, net := range container.NetworkSettings.Networks {
+				own = append(own, net.NetworkID)
+			}
 		}
+		break
+	}
 
-		containerName := p.extractContainerName(container)
+	return own
+}
 
-		for netName, ipList := range containerIPs {
-			if p.shouldInclude(containerName, ipList, listFilter, hasFilter, whiteList) {
-				ips[netName] = append(ips[netName], ipList...)
+// Watch запускает отслеживание контейнеров по событиям Docker до отмены ctx, удаленные сети забываются
+func (p *GoPinger) Watch(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case net := <-p.inventory.RemovedNetworks():
+				p.access.Forget(net)
 			}
 		}
+	}()
+
+	p.inventory.Run(ctx)
+}
+
+// Updates возвращает канал с ID новых и измененных контейнеров
+func (p *GoPinger) Updates() <-chan string {
+	return p.inventory.Updates()
+}
+
+// Events возвращает канал с событиями жизненного цикла контейнеров
+func (p *GoPinger) Events() <-chan []contracts.ContainerEvent {
+	return p.inventory.Events()
+}
+
+// Stopped возвращает канал с целями упавших и остановленных контейнеров
+func (p *GoPinger) Stopped() <-chan []Target {
+	return p.inventory.Stopped()
+}
+
+// GetTargets возвращает мапу сеть-цели пинга, отобранные фильтром f. Собственный контейнер
+// pinger'а не проверяется
+func (p *GoPinger) GetTargets(f *Filter) map[string][]Target {
+	targets := map[string][]Target{}
+	for _, t := range p.inventory.Targets() {
+		if t.ContainerID != p.id && f.Match(t) {
+			targets[t.NetworkID] = append(targets[t.NetworkID], t)
+		}
 	}
-	p.log.Debug("successful get containers", slog.Any("containers ips:", ips))
-	return ips
+	p.log.Debug("successful get containers", slog.Int("networks", len(targets)))
+	return targets
 }
 
 // getContainerList получает список всех найденных контейнеров
@@ -166,16 +269,6 @@ func (p *GoPinger) getContainerList() ([]types.Container, error) {
 	return containers, nil
 }
 
-// inspectContainer проверяет контейнер по айди и возвращает его параметы
-func (p *GoPinger) inspectContainer(id string) (types.ContainerJSON, error) {
-	inspect, err := p.cli.ContainerInspect(context.Background(), id)
-	if err != nil {
-		return types.ContainerJSON{}, err
-	}
-
-	return inspect, nil
-}
-
 // extractContainerName возвращает имя контейнера без префикса '/'
 func (p *GoPinger) extractContainerName(c types.Container) string {
 	if len(c.Names) == 0 {
@@ -185,92 +278,203 @@ func (p *GoPinger) extractContainerName(c types.Container) string {
 	return strings.TrimPrefix(c.Names[0], "/")
 }
 
-// filterNetworkIPs возвращает список всех IP-адресов всех контейнеров
-func (p *GoPinger) filterNetworkIPs(container types.ContainerJSON) map[string][]string {
-	ips := map[string][]string{}
-	for netName, netSettings := range container.NetworkSettings.Networks {
-		ips[netSettings.NetworkID] = append(ips[netName], netSettings.IPAddress)
+// Ping пингует цель и возвращает данные о доступности контейнера в сети цели. Если для цели
+// настроены проверки сервиса, контейнер доступен только при успехе всех проверок, независимо от ICMP.
+// DNS-проверки только сообщаются backend'у. Если ICMP не подтверждает доступность (режим netns,
+// IPv6 без ICMPv6) и проверок сервиса нет или в метках проверок ошибка, результат публикуется
+// с ошибкой проверки. Проверка, прерванная отменой ctx, тоже публикуется с ошибкой проверки
+func (p *GoPinger) Ping(ctx context.Context, t Target) contracts.PingData {
+	p.log.Debug("starting ping", slog.String("network", t.Network), slog.String("IP", t.IP))
+	t, err := p.access.Prepare(t)
+	if err != nil {
+		p.log.Error("failed to switch network", slog.String("network", t.Network), slog.Any("error", err))
+		return failedPingData(t, false, fmt.Errorf("failed to access network: %w", err), nil)
 	}
-	p.log.Debug("get ips", slog.Any("ips", ips))
 
-	return ips
-}
+	probes := p.probe(ctx, t)
+	serviceUp, hasService := serviceReachable(probes)
 
-// shouldInclude фильрация IP адресов ips в соответствии с фильтром filter и его типом whiteList
-func (p *GoPinger) shouldInclude(name string, ips []string, filter map[string]struct{}, hasFilter, whitelist bool) bool {
-	if !hasFilter {
-		return true
+	if err = interrupted(ctx); err != nil {
+		return failedPingData(t, false, err, probes)
+	}
+	if t.HTTP != nil && t.HTTP.Invalid != nil {
+		return failedPingData(t, false, t.HTTP.Invalid, probes)
 	}
 
-	for _, ip := range ips {
-		_, matchIP := filter[ip]
-		_, matchName := filter[name]
-
-		if whitelist {
-			if matchIP || matchName {
-				return true
-			}
-		} else {
-			if matchIP || matchName {
-				return false
-			}
+	if err = p.icmpUnavailable(t); err != nil {
+		if hasService {
+			return newPingData(t, serviceUp, time.Now(), contracts.PingStats{}, probes)
 		}
+		return failedPingData(t, false, err, probes)
 	}
 
-	return !whitelist
-}
-
-// Ping пингует IP-адрес и возвращает данные о доступности контейнера в указанной сети
-func (p *GoPinger) Ping(net, IP string) contracts.PingData {
-	p.log.Debug("starting ping", slog.String("network", net), slog.String("IP", IP))
-	err := p.connectToNetwork(net)
+	pinger, err := ping.NewPinger(t.IP)
 	if err != nil {
-		p.log.Error("failed to switch network", slog.String("network", net), slog.Any("error", err))
-		return newPingData(IP, false, time.Now())
+		p.log.Error("failed to ping ", slog.String("IP", t.IP), slog.Any("error", err))
+		return failedPingData(t, hasService && serviceUp, err, probes)
 	}
 
-	pinger, err := ping.NewPinger(IP)
-	if err != nil {
-		p.log.Error("failed to ping ", slog.String("IP", IP), slog.Any("error", err))
-		return newPingData(IP, false, time.Now())
-	}
+	pinger.SetPrivileged(p.privileged)
 
 	pinger.Count = p.packetsCount
+	if t.PacketsCount > 0 {
+		pinger.Count = t.PacketsCount
+	}
 	pinger.Timeout = p.pingTimeout
+	if t.PingTimeout > 0 {
+		pinger.Timeout = t.PingTimeout
+	}
 
-	pinger.Run()
-	stats := pinger.Statistics()
+	stop := context.AfterFunc(ctx, pinger.Stop)
+	err = p.access.Run(t, pinger.Run)
+	stop()
+	if err != nil {
+		p.log.Error("failed to ping ", slog.String("IP", t.IP), slog.Any("error", err))
+		return failedPingData(t, hasService && serviceUp, err, probes)
+	}
+	if err = interrupted(ctx); err != nil {
+		return failedPingData(t, hasService && serviceUp, err, probes)
+	}
+	stats := newPingStats(pinger.Statistics())
+
+	if hasService {
+		return newPingData(t, serviceUp, time.Now(), stats, probes)
+	}
 
 	if stats.PacketsRecv > 0 {
-		p.log.Debug("successful ping", slog.String("IP", IP), slog.Any("PacketsSend", pinger.Count),
-			slog.Any("PacketsReceived", pinger.PacketsRecv))
-		return newPingData(IP, true, time.Now())
+		p.log.Debug("successful ping", slog.String("IP", t.IP), slog.Any("PacketsSend", stats.PacketsSent),
+			slog.Any("PacketsReceived", stats.PacketsRecv), slog.Any("AvgRtt", stats.AvgRtt))
+		return newPingData(t, true, time.Now(), stats, probes)
 	}
 
-	return newPingData(IP, false, time.Now())
+	return newPingData(t, false, time.Now(), stats, probes)
 }
 
-func newPingData(IP string, isReachable bool, LastPing time.Time) contracts.PingData {
-	return contracts.PingData{
-		IPAddress:   IP,
-		IsReachable: isReachable,
-		LastPing:    LastPing.Format(time.DateTime),
+// icmpUnavailable возвращает причину, по которой ICMP-пинг не подтверждает доступность цели t
+func (p *GoPinger) icmpUnavailable(t Target) error {
+	if !p.access.ICMPReliable() {
+		return errors.New("icmp ping inside the container network namespace always succeeds, configure service probes")
 	}
+	if t.Family == contracts.FamilyIPv6 && p.icmpV6 != nil {
+		return fmt.Errorf("icmp ping over ipv6 is unavailable: %w", p.icmpV6)
+	}
+
+	return nil
 }
 
-// connectToNetwork подключает pinger к сети указанной сети
-func (p *GoPinger) connectToNetwork(net string) error {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-	if _, ok := p.net[net]; ok {
-		return nil
+// interrupted возвращает ошибку проверки, если она прервана отменой ctx: неполные результаты
+// не означают недоступность контейнера
+func interrupted(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("check interrupted: %w", err)
 	}
-	err := p.cli.NetworkConnect(context.Background(), net, p.id, &network.EndpointSettings{})
+
+	return nil
+}
+
+// Probe выполняет проверки сервиса цели всеми probers
+func (p *GoPinger) Probe(ctx context.Context, t Target) []contracts.ProbeResult {
+	t, err := p.access.Prepare(t)
 	if err != nil {
-		return fmt.Errorf("failed to connect network: %w", err)
+		p.log.Error("failed to switch network", slog.String("network", t.Network), slog.Any("error", err))
+	}
+
+	return p.probe(ctx, t)
+}
+
+// probe выполняет проверки сервиса цели, доступ к сети которой уже подготовлен
+func (p *GoPinger) probe(ctx context.Context, t Target) []contracts.ProbeResult {
+	var results []contracts.ProbeResult
+	for _, prober := range p.probers {
+		for _, r := range prober.Probe(ctx, t) {
+			if !r.Success {
+				p.log.Debug("probe failed", slog.String("IP", t.IP), slog.String("type", r.Type),
+					slog.Int("port", r.Port), slog.String("error", r.Error))
+			}
+			results = append(results, r)
+		}
+	}
+
+	return results
+}
+
+func newPingData(
+	t Target,
+	isReachable bool,
+	LastPing time.Time,
+	stats contracts.PingStats,
+	probes []contracts.ProbeResult,
+) contracts.PingData {
+	return contracts.PingData{
+		IPAddress:     t.IP,
+		Family:        t.Family,
+		IsReachable:   isReachable,
+		LastPing:      LastPing.Format(time.DateTime),
+		ContainerInfo: t.ContainerInfo,
+		PingStats:     stats,
+		Probes:        probes,
+		Health:        t.Health,
 	}
-	p.net[net] = struct{}{}
-	return nil
+}
+
+// StoppedPingData возвращает последний результат цели t остановленного в момент at контейнера:
+// контейнер недоступен, проверки не выполнялись
+func StoppedPingData(t Target, at time.Time) contracts.PingData {
+	return newPingData(t, false, at, contracts.PingStats{}, nil)
+}
+
+// failedPingData возвращает результат цели t, ICMP-пинг которой не удалось выполнить из-за err.
+// Доступность определяется только проверками сервиса
+func failedPingData(t Target, isReachable bool, err error, probes []contracts.ProbeResult) contracts.PingData {
+	data := newPingData(t, isReachable, time.Now(), contracts.PingStats{}, probes)
+	data.CheckError = err.Error()
+
+	return data
+}
+
+// newPingStats переводит статистику go-ping в контракт, jitter считается как среднее
+// отклонение между соседними RTT
+func newPingStats(stats *ping.Statistics) contracts.PingStats {
+	var jitter time.Duration
+	if len(stats.Rtts) > 1 {
+		for i := 1; i < len(stats.Rtts); i++ {
+			diff := stats.Rtts[i] - stats.Rtts[i-1]
+			if diff < 0 {
+				diff = -diff
+			}
+			jitter += diff
+		}
+		jitter /= time.Duration(len(stats.Rtts) - 1)
+	}
+
+	return contracts.PingStats{
+		PacketsSent: stats.PacketsSent,
+		PacketsRecv: stats.PacketsRecv,
+		PacketLoss:  stats.PacketLoss,
+		MinRtt:      toMilliseconds(stats.MinRtt),
+		AvgRtt:      toMilliseconds(stats.AvgRtt),
+		MaxRtt:      toMilliseconds(stats.MaxRtt),
+		StdDevRtt:   toMilliseconds(stats.StdDevRtt),
+		Jitter:      toMilliseconds(jitter),
+	}
+}
+
+// toMilliseconds переводит длительность в миллисекунды
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// ReleaseNetworks отключает pinger от динамически подключенных сетей, в которых нет целей targets
+// дольше времени простоя. Собственная конечная точка pinger'а в сети не делает ее активной
+func (p *GoPinger) ReleaseNetworks(targets map[string][]Target) {
+	active := make([]string, 0, len(targets))
+	for net, netTargets := range targets {
+		if slices.ContainsFunc(netTargets, func(t Target) bool { return t.ContainerID != p.id }) {
+			active = append(active, net)
+		}
+	}
+
+	p.access.Release(active, time.Now())
 }
 
 // SendRequest отправляет запрос на адрес rabbitmq с информацией о пингах data
@@ -288,3 +492,19 @@ func (p *GoPinger) SendRequest(data []contracts.PingData) error {
 
 	return nil
 }
+
+// SendEvents отправляет в очередь событий rabbitmq события жизненного цикла контейнеров events
+func (p *GoPinger) SendEvents(events []contracts.ContainerEvent) error {
+	req := contracts.ContainerEventReq{Events: events}
+
+	if !req.IsValid() {
+		return fmt.Errorf("failed to send events: %w", errors.New("invalid request"))
+	}
+
+	err := p.eventsMQ.Publish(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish events: %w", err)
+	}
+
+	return nil
+}
@@ -1,15 +1,178 @@
 package service
 
 import (
+	"app-pinger/pinger/config"
 	"app-pinger/pkg/contracts"
 	mockqueue "app-pinger/pkg/queue/mock"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/go-ping/ping"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
 
+// newFakeDockerAPI возвращает клиент Docker, подключенный к HTTP-серверу с контейнерами containers
+func newFakeDockerAPI(t *testing.T, containers map[string]types.ContainerJSON) *client.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case len(parts) == 3 && parts[1] == "containers" && parts[2] == "json":
+			var list []types.Container
+			for id, c := range containers {
+				networks := map[string]*network.EndpointSettings{}
+				for name, net := range c.NetworkSettings.Networks {
+					networks[name] = net
+				}
+				list = append(list, types.Container{ID: id, Names: []string{c.Name}, State: "running",
+					Labels: c.Config.Labels, NetworkSettings: &types.SummaryNetworkSettings{Networks: networks}})
+			}
+			json.NewEncoder(w).Encode(list)
+		case len(parts) == 4 && parts[1] == "containers" && parts[3] == "json":
+			c, ok := containers[parts[2]]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(c)
+		case len(parts) == 3 && parts[1] == "networks":
+			json.NewEncoder(w).Encode(network.Inspect{ID: parts[2]})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://"+server.Listener.Addr().String()),
+		client.WithVersion("1.47"))
+	require.NoError(t, err)
+
+	return cli
+}
+
+func TestNewGoPingerService_ExcludesOwnContainer(t *testing.T) {
+	for _, mode := range []string{config.AccessConnect, config.AccessHost, config.AccessNetns} {
+		t.Run(mode, func(t *testing.T) {
+			cli := newFakeDockerAPI(t, map[string]types.ContainerJSON{
+				"a":      newFakeContainer("a", "backend", "172.10.0.2", true),
+				"pinger": newFakeContainer("pinger", "pinger", "172.10.0.3", true),
+			})
+
+			access := config.Access{Mode: mode, Idle: time.Minute, ProcPath: "/proc", ICMP: config.ICMPAuto}
+			pinger := NewGoPingerService(cli, slog.Default(), 1, time.Second, time.Minute, access, "pinger", nil, nil)
+			pinger.inventory.Sync(context.Background())
+
+			filter, err := NewFilter(config.Filter{})
+			require.NoError(t, err)
+
+			targets := pinger.GetTargets(filter)
+			require.Len(t, targets["net1"], 1)
+			require.Equal(t, "a", targets["net1"][0].ContainerID)
+		})
+	}
+}
+
+func TestNewGoPingerService_StartupNetworksAreStatic(t *testing.T) {
+	// pinger запущен через compose, но его сеть не принадлежит проекту (например, внешняя сеть с RabbitMQ)
+	own := newFakeContainer("pinger", "pinger", "172.10.0.3", true)
+	own.Config.Labels = map[string]string{composeProjectLabel: "app"}
+	cli := newFakeDockerAPI(t, map[string]types.ContainerJSON{"pinger": own})
+
+	access := config.Access{Mode: config.AccessConnect, Idle: time.Minute, ICMP: config.ICMPAuto}
+	pinger := NewGoPingerService(cli, slog.Default(), 1, time.Second, time.Minute, access, "pinger", nil, nil)
+
+	require.Empty(t, pinger.access.Release(nil, time.Now().Add(time.Hour)))
+}
+
+// proberFunc проверка сервиса, заданная функцией
+type proberFunc func(ctx context.Context, t Target) []contracts.ProbeResult
+
+func (f proberFunc) Probe(ctx context.Context, t Target) []contracts.ProbeResult {
+	return f(ctx, t)
+}
+
+func TestGoPinger_PingUnreliableICMP(t *testing.T) {
+	tcpUp := proberFunc(func(ctx context.Context, t Target) []contracts.ProbeResult {
+		return []contracts.ProbeResult{{Type: contracts.ProbeTCP, Port: 80, Success: true}}
+	})
+
+	tests := []struct {
+		name      string
+		access    NetAccess
+		icmpV6    error
+		ip        string
+		family    string
+		probers   []Prober
+		reachable bool
+		unknown   bool
+	}{
+		{
+			name:    "Netns without probes",
+			access:  NewNetnsAccess("/proc"),
+			ip:      "10.0.0.2",
+			family:  contracts.FamilyIPv4,
+			unknown: true,
+		},
+		{
+			name:      "Netns with probes",
+			access:    NewNetnsAccess("/proc"),
+			ip:        "10.0.0.2",
+			family:    contracts.FamilyIPv4,
+			probers:   []Prober{tcpUp},
+			reachable: true,
+		},
+		{
+			name:    "IPv6 without icmpv6",
+			access:  HostAccess{},
+			icmpV6:  errors.New("socket: address family not supported by protocol"),
+			ip:      "fd00::2",
+			family:  contracts.FamilyIPv6,
+			unknown: true,
+		},
+		{
+			name:      "IPv6 without icmpv6 with probes",
+			access:    HostAccess{},
+			icmpV6:    errors.New("socket: address family not supported by protocol"),
+			ip:        "fd00::2",
+			family:    contracts.FamilyIPv6,
+			probers:   []Prober{tcpUp},
+			reachable: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &GoPinger{log: *slog.Default(), access: tt.access, icmpV6: tt.icmpV6, probers: tt.probers}
+
+			data := p.Ping(context.Background(), Target{IP: tt.ip, Family: tt.family, PID: 1})
+
+			require.Equal(t, tt.reachable, data.IsReachable)
+			require.Equal(t, tt.unknown, data.CheckError != "")
+		})
+	}
+}
+
+func TestGoPinger_PingInvalidConfig(t *testing.T) {
+	p := &GoPinger{log: *slog.Default(), access: HostAccess{}, probers: []Prober{NewHTTPProber(time.Second)}}
+
+	check := newHTTPCheck(map[string]string{httpPathLabel: "/", httpRegexLabel: "(ok"}, []int{8080})
+	data := p.Ping(context.Background(), Target{IP: "10.0.0.2", Family: contracts.FamilyIPv4, HTTP: check})
+
+	require.False(t, data.IsReachable)
+	require.Contains(t, data.CheckError, httpRegexLabel)
+	require.Len(t, data.Probes, 1)
+	require.False(t, data.Probes[0].Success)
+}
+
 func TestGoPinger_SendRequest(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -110,3 +273,112 @@ func TestGoPinger_SendRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestGoPinger_SendEvents(t *testing.T) {
+	tests := []struct {
+		name          string
+		events        []contracts.ContainerEvent
+		expectedError string
+	}{
+		{
+			name: "Valid send",
+			events: []contracts.ContainerEvent{
+				{
+					Action:        contracts.ActionDie,
+					Time:          time.Now().Format(time.DateTime),
+					ExitCode:      1,
+					ContainerInfo: contracts.ContainerInfo{Key: "app.web.1@app_default"},
+				},
+			},
+		},
+		{
+			name: "Invalid send (no key)",
+			events: []contracts.ContainerEvent{
+				{
+					Action: contracts.ActionStart,
+					Time:   time.Now().Format(time.DateTime),
+				},
+			},
+			expectedError: "invalid request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRabbit := &mockqueue.MockRabbitMQ{}
+			mockRabbit.On("Publish", mock.Anything).Return(nil)
+			pinger := &GoPinger{eventsMQ: mockRabbit}
+
+			err := pinger.SendEvents(tt.events)
+
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+				mockRabbit.AssertCalled(t, "Publish", contracts.ContainerEventReq{Events: tt.events})
+			} else {
+				require.ErrorContains(t, err, tt.expectedError)
+				mockRabbit.AssertNotCalled(t, "Publish", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestNewPingStats(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats *ping.Statistics
+		want  contracts.PingStats
+	}{
+		{
+			name:  "No packets received",
+			stats: &ping.Statistics{PacketsSent: 4, PacketLoss: 100},
+			want:  contracts.PingStats{PacketsSent: 4, PacketLoss: 100},
+		},
+		{
+			name: "Single packet (no jitter)",
+			stats: &ping.Statistics{
+				PacketsSent: 1,
+				PacketsRecv: 1,
+				Rtts:        []time.Duration{2 * time.Millisecond},
+				MinRtt:      2 * time.Millisecond,
+				AvgRtt:      2 * time.Millisecond,
+				MaxRtt:      2 * time.Millisecond,
+			},
+			want: contracts.PingStats{
+				PacketsSent: 1,
+				PacketsRecv: 1,
+				MinRtt:      2,
+				AvgRtt:      2,
+				MaxRtt:      2,
+			},
+		},
+		{
+			name: "Packet loss with jitter",
+			stats: &ping.Statistics{
+				PacketsSent: 4,
+				PacketsRecv: 3,
+				PacketLoss:  25,
+				Rtts:        []time.Duration{time.Millisecond, 3 * time.Millisecond, 2 * time.Millisecond},
+				MinRtt:      time.Millisecond,
+				AvgRtt:      2 * time.Millisecond,
+				MaxRtt:      3 * time.Millisecond,
+				StdDevRtt:   1500 * time.Microsecond,
+			},
+			want: contracts.PingStats{
+				PacketsSent: 4,
+				PacketsRecv: 3,
+				PacketLoss:  25,
+				MinRtt:      1,
+				AvgRtt:      2,
+				MaxRtt:      3,
+				StdDevRtt:   1.5,
+				Jitter:      1.5,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, newPingStats(tt.stats))
+		})
+	}
+}
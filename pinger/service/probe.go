@@ -0,0 +1,79 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Prober проверка сервиса контейнера, возвращает результаты для всех настроенных у цели проверок
+// своего типа. Сеть цели к моменту вызова уже подключена, подключения выполняются через Target.Dial.
+// Проверки прерываются при отмене ctx
+type Prober interface {
+	Probe(ctx context.Context, t Target) []contracts.ProbeResult
+}
+
+// TCPProber проверяет, принимает ли контейнер соединения на TCP-портах цели
+type TCPProber struct {
+	timeout time.Duration
+}
+
+// check for implementation
+var _ Prober = (*TCPProber)(nil)
+
+func NewTCPProber(timeout time.Duration) *TCPProber {
+	return &TCPProber{timeout: timeout}
+}
+
+func (p *TCPProber) Probe(ctx context.Context, t Target) []contracts.ProbeResult {
+	if len(t.TCPPorts) == 0 {
+		return nil
+	}
+
+	results := make([]contracts.ProbeResult, len(t.TCPPorts))
+	for i, port := range t.TCPPorts {
+		results[i] = probeTCP(ctx, t, port, p.timeout)
+	}
+
+	return results
+}
+
+// probeTCP устанавливает TCP-соединение с портом port цели t и замеряет время подключения
+func probeTCP(ctx context.Context, t Target, port int, timeout time.Duration) contracts.ProbeResult {
+	result := contracts.ProbeResult{Type: contracts.ProbeTCP, Port: port}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	conn, err := t.dial(ctx, "tcp", net.JoinHostPort(t.IP, strconv.Itoa(port)))
+	result.Latency = toMilliseconds(time.Since(start))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	conn.Close()
+
+	result.Success = true
+	return result
+}
+
+// serviceReachable определяет доступность сервиса по результатам проверок: сервис доступен,
+// если все проверки прошли успешно. DNS-проверки не учитываются, ok равен false,
+// если других проверок нет
+func serviceReachable(results []contracts.ProbeResult) (reachable bool, ok bool) {
+	reachable = true
+	for _, r := range results {
+		if r.Type == contracts.ProbeDNS {
+			continue
+		}
+		ok = true
+		if !r.Success {
+			reachable = false
+		}
+	}
+
+	return reachable, ok
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+)
+
+// dnsNamesLabel список имен для DNS-проверки через запятую, заменяет имя сервиса и алиасы сети,
+// пустое значение отключает DNS-проверки
+const dnsNamesLabel = "pinger.dns.names"
+
+// lookupFunc разрешает имя host в адреса
+type lookupFunc func(ctx context.Context, host string) ([]string, error)
+
+// DNSProber проверяет, что имена контейнера (имя compose-сервиса и алиасы в сети) разрешаются
+// в IP-адрес цели. Результат DNS-проверки не влияет на доступность контейнера
+type DNSProber struct {
+	timeout time.Duration
+	server  string
+	lookup  lookupFunc
+}
+
+// check for implementation
+var _ Prober = (*DNSProber)(nil)
+
+// NewDNSProber создает проверку, использующую DNS-сервер server (host:port),
+// при пустом server используется системный резолвер (внутри контейнера - DNS Docker)
+func NewDNSProber(timeout time.Duration, server string) *DNSProber {
+	resolver := net.DefaultResolver
+	if server != "" {
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, server)
+			},
+		}
+	}
+
+	return &DNSProber{timeout: timeout, server: server, lookup: resolver.LookupHost}
+}
+
+func (p *DNSProber) Probe(ctx context.Context, t Target) []contracts.ProbeResult {
+	lookup := p.lookup
+	if t.Dial != nil {
+		lookup = p.resolver(t.Dial).LookupHost
+	}
+
+	results := make([]contracts.ProbeResult, 0, len(t.DNSNames))
+	for _, name := range t.DNSNames {
+		results = append(results, p.probe(ctx, lookup, t.IP, name))
+	}
+
+	return results
+}
+
+// resolver возвращает резолвер, который обращается к DNS-серверу через dial, то есть из сети цели.
+// Без server используется адрес из resolv.conf pinger'а (в контейнере - DNS Docker 127.0.0.11)
+func (p *DNSProber) resolver(dial DialFunc) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			if p.server != "" {
+				address = p.server
+			}
+			return dial(ctx, network, address)
+		},
+	}
+}
+
+// probe разрешает имя name через lookup и проверяет, что среди ответов есть IP
+func (p *DNSProber) probe(ctx context.Context, lookup lookupFunc, IP, name string) contracts.ProbeResult {
+	result := contracts.ProbeResult{Type: contracts.ProbeDNS, Target: name}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	start := time.Now()
+	addresses, err := lookup(ctx, name)
+	result.Latency = toMilliseconds(time.Since(start))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Addresses = addresses
+	if !slices.ContainsFunc(addresses, func(address string) bool { return sameIP(address, IP) }) {
+		result.Error = fmt.Sprintf("resolved to %s, want %s", strings.Join(addresses, ", "), IP)
+		return result
+	}
+
+	result.Success = true
+	return result
+}
+
+// dnsNames возвращает имена контейнера в сети: из метки dnsNamesLabel, а при ее отсутствии -
+// имя compose-сервиса, алиасы и DNS-имена конечной точки
+func dnsNames(labels map[string]string, aliases ...[]string) []string {
+	if value, ok := labels[dnsNamesLabel]; ok {
+		var names []string
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+
+		return names
+	}
+
+	var names []string
+	if service := labels[composeServiceLabel]; service != "" {
+		names = append(names, service)
+	}
+	for _, list := range aliases {
+		for _, name := range list {
+			if name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// sameIP сравнивает адреса без учета формы записи (сокращение нулей в IPv6, регистр)
+func sameIP(a, b string) bool {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return addrA.Unmap() == addrB.Unmap()
+}
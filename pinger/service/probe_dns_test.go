@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDNSProber_Probe(t *testing.T) {
+	answers := map[string][]string{
+		"backend":       {"172.10.0.2"},
+		"app-backend-2": {"172.10.0.2", "172.20.0.2"},
+		"stale":         {"172.10.0.9"},
+	}
+
+	prober := &DNSProber{
+		timeout: time.Second,
+		lookup: func(ctx context.Context, host string) ([]string, error) {
+			if addresses, ok := answers[host]; ok {
+				return addresses, nil
+			}
+			return nil, errors.New("no such host")
+		},
+	}
+
+	results := prober.Probe(context.Background(), Target{IP: "172.10.0.2", DNSNames: []string{"backend", "app-backend-2", "stale", "unknown"}})
+	require.Len(t, results, 4)
+
+	want := []bool{true, true, false, false}
+	for i, r := range results {
+		require.Equal(t, want[i], r.Success, r.Target)
+	}
+	require.Equal(t, []string{"172.10.0.9"}, results[2].Addresses)
+	require.Equal(t, "resolved to 172.10.0.9, want 172.10.0.2", results[2].Error)
+	require.Equal(t, "no such host", results[3].Error)
+
+	// IPv6-адрес сравнивается без учета формы записи
+	answers["backend"] = []string{"172.10.0.2", "fd00:10::2"}
+	results = prober.Probe(context.Background(), Target{IP: "FD00:10:0:0::2", DNSNames: []string{"backend"}})
+	require.Len(t, results, 1)
+	require.True(t, results[0].Success)
+}
+
+func TestDNSNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		aliases [][]string
+		want    []string
+	}{
+		{
+			name:    "Service name and aliases",
+			labels:  map[string]string{composeServiceLabel: "backend"},
+			aliases: [][]string{{"backend", "app-backend-1"}, {"app-backend-1", "4f1c2d3e5a6b"}},
+			want:    []string{"backend", "app-backend-1", "4f1c2d3e5a6b"},
+		},
+		{
+			name:    "Names from label",
+			labels:  map[string]string{composeServiceLabel: "backend", dnsNamesLabel: "api, api.internal"},
+			aliases: [][]string{{"backend"}},
+			want:    []string{"api", "api.internal"},
+		},
+		{
+			name:    "Disabled by label",
+			labels:  map[string]string{dnsNamesLabel: ""},
+			aliases: [][]string{{"backend"}},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, dnsNames(tt.labels, tt.aliases...))
+		})
+	}
+}
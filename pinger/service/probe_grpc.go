@@ -0,0 +1,110 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	grpcPortLabel    = "pinger.grpc.port"
+	grpcServiceLabel = "pinger.grpc.service"
+	grpcTimeoutLabel = "pinger.grpc.timeout"
+)
+
+// GRPCCheck настройки проверки по протоколу grpc.health.v1, задаются метками pinger.grpc.*.
+// Пустой Service означает проверку состояния сервера целиком
+type GRPCCheck struct {
+	Port    int
+	Service string
+	Timeout time.Duration
+}
+
+// newGRPCCheck возвращает настройки gRPC-проверки по меткам контейнера или nil, если не задан порт
+func newGRPCCheck(labels map[string]string) *GRPCCheck {
+	port := parsePorts(labels[grpcPortLabel])
+	if len(port) == 0 {
+		return nil
+	}
+
+	check := &GRPCCheck{Port: port[0], Service: labels[grpcServiceLabel]}
+	if timeout, err := time.ParseDuration(labels[grpcTimeoutLabel]); err == nil && timeout > 0 {
+		check.Timeout = timeout
+	}
+
+	return check
+}
+
+// GRPCProber выполняет вызов grpc.health.v1.Health/Check к контейнерам, у которых настроена gRPC-проверка.
+// Проверка успешна, только если сервис отвечает SERVING
+type GRPCProber struct {
+	timeout time.Duration
+}
+
+// check for implementation
+var _ Prober = (*GRPCProber)(nil)
+
+func NewGRPCProber(timeout time.Duration) *GRPCProber {
+	return &GRPCProber{timeout: timeout}
+}
+
+func (p *GRPCProber) Probe(ctx context.Context, t Target) []contracts.ProbeResult {
+	if t.GRPC == nil {
+		return nil
+	}
+
+	return []contracts.ProbeResult{p.probe(ctx, t, t.GRPC)}
+}
+
+// probe запрашивает состояние сервиса check у цели t
+func (p *GRPCProber) probe(ctx context.Context, t Target, check *GRPCCheck) contracts.ProbeResult {
+	address := net.JoinHostPort(t.IP, strconv.Itoa(check.Port))
+	result := contracts.ProbeResult{Type: contracts.ProbeGRPC, Target: address, Port: check.Port}
+	if check.Service != "" {
+		result.Target += "/" + check.Service
+	}
+
+	timeout := p.timeout
+	if check.Timeout > 0 {
+		timeout = check.Timeout
+	}
+
+	options := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if t.Dial != nil {
+		options = append(options, grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return t.Dial(ctx, "tcp", address)
+		}))
+	}
+
+	conn, err := grpc.NewClient(address, options...)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: check.Service})
+	result.Latency = toMilliseconds(time.Since(start))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = resp.GetStatus().String()
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		result.Error = "service is " + result.Status
+		return result
+	}
+
+	result.Success = true
+	return result
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestGRPCProber_Probe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("app.Orders", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("app.Payments", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed := closedListener.Addr().(*net.TCPAddr).Port
+	closedListener.Close()
+
+	tests := []struct {
+		name   string
+		check  GRPCCheck
+		want   bool
+		status string
+	}{
+		{
+			name:   "Server serving",
+			check:  GRPCCheck{Port: port},
+			want:   true,
+			status: "SERVING",
+		},
+		{
+			name:   "Service serving",
+			check:  GRPCCheck{Port: port, Service: "app.Orders"},
+			want:   true,
+			status: "SERVING",
+		},
+		{
+			name:   "Service not serving",
+			check:  GRPCCheck{Port: port, Service: "app.Payments"},
+			want:   false,
+			status: "NOT_SERVING",
+		},
+		{
+			name:  "Unknown service",
+			check: GRPCCheck{Port: port, Service: "app.Unknown"},
+			want:  false,
+		},
+		{
+			name:  "Server down",
+			check: GRPCCheck{Port: closed, Timeout: 200 * time.Millisecond},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := NewGRPCProber(time.Second).Probe(context.Background(), Target{IP: "127.0.0.1", GRPC: &tt.check})
+			require.Len(t, results, 1)
+
+			require.Equal(t, tt.want, results[0].Success, results[0].Error)
+			require.Equal(t, tt.status, results[0].Status)
+			require.Equal(t, tt.want, results[0].Error == "")
+		})
+	}
+}
+
+func TestNewGRPCCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   *GRPCCheck
+	}{
+		{
+			name:   "No port label",
+			labels: map[string]string{grpcServiceLabel: "app.Orders"},
+			want:   nil,
+		},
+		{
+			name:   "Port, service and timeout",
+			labels: map[string]string{grpcPortLabel: "50051", grpcServiceLabel: "app.Orders", grpcTimeoutLabel: "1s"},
+			want:   &GRPCCheck{Port: 50051, Service: "app.Orders", Timeout: time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, newGRPCCheck(tt.labels))
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	httpPathLabel       = "pinger.http.path"
+	httpPortLabel       = "pinger.http.port"
+	httpSchemeLabel     = "pinger.http.scheme"
+	httpMethodLabel     = "pinger.http.method"
+	httpStatusLabel     = "pinger.http.status"
+	httpBodyLabel       = "pinger.http.body"
+	httpRegexLabel      = "pinger.http.regex"
+	httpTimeoutLabel    = "pinger.http.timeout"
+	httpSkipVerifyLabel = "pinger.http.tls-skip-verify"
+	// httpHostLabel имя хоста для заголовка Host и проверки сертификата (SNI), по умолчанию - IP контейнера
+	httpHostLabel = "pinger.http.host"
+
+	// maxHTTPBody ограничение на размер тела ответа, в котором ищется Body или Regex
+	maxHTTPBody = 1 << 20
+)
+
+// HTTPCheck настройки HTTP-проверки контейнера, задаются метками pinger.http.*.
+// Ответ считается успешным, если код входит в [StatusMin, StatusMax], а тело содержит Body
+// и подходит под Regex (если они заданы). Host заменяет IP контейнера в заголовке Host
+// и при проверке TLS-сертификата. Invalid - ошибка в метках проверки, с ней запрос не выполняется
+type HTTPCheck struct {
+	Scheme     string
+	Host       string
+	Method     string
+	Path       string
+	Port       int
+	StatusMin  int
+	StatusMax  int
+	Body       string
+	Regex      *regexp.Regexp
+	Timeout    time.Duration
+	SkipVerify bool
+	Invalid    error
+}
+
+// newHTTPCheck возвращает настройки HTTP-проверки по меткам контейнера или nil, если не задана
+// метка пути. Если порт не указан, для https используется 443, для http - первый TCP-порт контейнера или 80.
+// Некорректные значения меток заменяются значениями по умолчанию, регулярное выражение компилируется
+// один раз, ошибка в нем сохраняется в Invalid
+func newHTTPCheck(labels map[string]string, ports []int) *HTTPCheck {
+	path, ok := labels[httpPathLabel]
+	if !ok {
+		return nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	check := &HTTPCheck{
+		Scheme:    "http",
+		Method:    http.MethodGet,
+		Path:      path,
+		StatusMin: 200,
+		StatusMax: 399,
+		Host:      strings.TrimSpace(labels[httpHostLabel]),
+		Body:      labels[httpBodyLabel],
+	}
+
+	if expr := labels[httpRegexLabel]; expr != "" {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			check.Invalid = fmt.Errorf("invalid %s label: %w", httpRegexLabel, err)
+		}
+		check.Regex = re
+	}
+
+	if labels[httpSchemeLabel] == "https" {
+		check.Scheme = "https"
+	}
+	if method := labels[httpMethodLabel]; method != "" {
+		check.Method = strings.ToUpper(method)
+	}
+	if low, high, ok := parseStatusRange(labels[httpStatusLabel]); ok {
+		check.StatusMin, check.StatusMax = low, high
+	}
+	if timeout, err := time.ParseDuration(labels[httpTimeoutLabel]); err == nil && timeout > 0 {
+		check.Timeout = timeout
+	}
+	check.SkipVerify, _ = strconv.ParseBool(labels[httpSkipVerifyLabel])
+
+	switch port := parsePorts(labels[httpPortLabel]); {
+	case len(port) > 0:
+		check.Port = port[0]
+	case check.Scheme == "https":
+		check.Port = 443
+	case len(ports) > 0:
+		check.Port = ports[0]
+	default:
+		check.Port = 80
+	}
+
+	return check
+}
+
+// parseStatusRange разбирает код ответа (200) или диапазон кодов (200-299)
+func parseStatusRange(value string) (int, int, bool) {
+	if value == "" {
+		return 0, 0, false
+	}
+
+	lowStr, highStr, isRange := strings.Cut(value, "-")
+	if !isRange {
+		highStr = lowStr
+	}
+
+	low, err := strconv.Atoi(strings.TrimSpace(lowStr))
+	if err != nil {
+		return 0, 0, false
+	}
+	high, err := strconv.Atoi(strings.TrimSpace(highStr))
+	if err != nil || low < 100 || high > 599 || low > high {
+		return 0, 0, false
+	}
+
+	return low, high, true
+}
+
+// URL возвращает адрес проверки для IP-адреса контейнера
+func (c *HTTPCheck) URL(IP string) string {
+	return fmt.Sprintf("%s://%s%s", c.Scheme, net.JoinHostPort(IP, strconv.Itoa(c.Port)), c.Path)
+}
+
+// HTTPProber выполняет HTTP-запросы к контейнерам, у которых настроена HTTP-проверка
+type HTTPProber struct {
+	timeout time.Duration
+}
+
+// check for implementation
+var _ Prober = (*HTTPProber)(nil)
+
+func NewHTTPProber(timeout time.Duration) *HTTPProber {
+	return &HTTPProber{timeout: timeout}
+}
+
+func (p *HTTPProber) Probe(ctx context.Context, t Target) []contracts.ProbeResult {
+	if t.HTTP == nil {
+		return nil
+	}
+
+	return []contracts.ProbeResult{p.probe(ctx, t, t.HTTP)}
+}
+
+// probe выполняет запрос check к цели t и проверяет ответ
+func (p *HTTPProber) probe(ctx context.Context, t Target, check *HTTPCheck) contracts.ProbeResult {
+	result := contracts.ProbeResult{Type: contracts.ProbeHTTP, Target: check.URL(t.IP), Port: check.Port}
+
+	if check.Invalid != nil {
+		result.Error = check.Invalid.Error()
+		return result
+	}
+
+	timeout := p.timeout
+	if check.Timeout > 0 {
+		timeout = check.Timeout
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: check.SkipVerify, ServerName: check.Host},
+			DisableKeepAlives: true,
+			DialContext:       t.dial,
+		},
+		// редиректы не выполняются, код 3xx проверяется по диапазону
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, check.Method, result.Target, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if check.Host != "" {
+		req.Host = check.Host
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Latency = toMilliseconds(time.Since(start))
+		result.Error = err.Error()
+
+		// срок действия сертификата, не прошедшего проверку, тоже сохраняется
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			result.CertExpiry = certExpiry(certErr.UnverifiedCertificates)
+		}
+		return result
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBody))
+	result.Latency = toMilliseconds(time.Since(start))
+	result.StatusCode = resp.StatusCode
+
+	if resp.TLS != nil {
+		result.CertExpiry = certExpiry(resp.TLS.PeerCertificates)
+	}
+
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to read body: %s", err)
+		return result
+	}
+	if resp.StatusCode < check.StatusMin || resp.StatusCode > check.StatusMax {
+		result.Error = fmt.Sprintf("unexpected status code %d, want %d-%d", resp.StatusCode,
+			check.StatusMin, check.StatusMax)
+		return result
+	}
+	if check.Body != "" && !strings.Contains(string(body), check.Body) {
+		result.Error = fmt.Sprintf("body does not contain %q", check.Body)
+		return result
+	}
+	if check.Regex != nil && !check.Regex.Match(body) {
+		result.Error = fmt.Sprintf("body does not match %q", check.Regex)
+		return result
+	}
+
+	result.Success = true
+	return result
+}
+
+// certExpiry возвращает окончание действия сертификата сервера из цепочки certs
+// или пустую строку, если сертификатов нет
+func certExpiry(certs []*x509.Certificate) string {
+	if len(certs) == 0 {
+		return ""
+	}
+
+	return certs[0].NotAfter.UTC().Format(time.DateTime)
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPCheck(t *testing.T) {
+	_, invalidRegex := regexp.Compile("(ok")
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		ports  []int
+		want   *HTTPCheck
+	}{
+		{
+			name:   "No path label",
+			labels: map[string]string{httpPortLabel: "8080"},
+			want:   nil,
+		},
+		{
+			name:   "Defaults",
+			labels: map[string]string{httpPathLabel: "healthz"},
+			ports:  []int{8080, 9090},
+			want: &HTTPCheck{
+				Scheme: "http", Method: http.MethodGet, Path: "/healthz", Port: 8080, StatusMin: 200, StatusMax: 399,
+			},
+		},
+		{
+			name: "All labels",
+			labels: map[string]string{
+				httpPathLabel:       "/ready",
+				httpPortLabel:       "8443",
+				httpSchemeLabel:     "https",
+				httpMethodLabel:     "head",
+				httpStatusLabel:     "200-204",
+				httpBodyLabel:       "ok",
+				httpRegexLabel:      "^ok$",
+				httpTimeoutLabel:    "3s",
+				httpSkipVerifyLabel: "true",
+				httpHostLabel:       "api.example.com",
+			},
+			want: &HTTPCheck{
+				Scheme: "https", Host: "api.example.com", Method: http.MethodHead, Path: "/ready", Port: 8443,
+				StatusMin: 200, StatusMax: 204, Body: "ok", Regex: regexp.MustCompile("^ok$"), Timeout: 3 * time.Second,
+				SkipVerify: true,
+			},
+		},
+		{
+			name:   "HTTPS without port label uses 443",
+			labels: map[string]string{httpPathLabel: "/", httpSchemeLabel: "https"},
+			ports:  []int{8080},
+			want: &HTTPCheck{
+				Scheme: "https", Method: http.MethodGet, Path: "/", Port: 443, StatusMin: 200, StatusMax: 399,
+			},
+		},
+		{
+			name:   "Invalid regex",
+			labels: map[string]string{httpPathLabel: "/", httpRegexLabel: "(ok"},
+			ports:  []int{8080},
+			want: &HTTPCheck{
+				Scheme: "http", Method: http.MethodGet, Path: "/", Port: 8080, StatusMin: 200, StatusMax: 399,
+				Invalid: fmt.Errorf("invalid %s label: %w", httpRegexLabel, invalidRegex),
+			},
+		},
+		{
+			name:   "Invalid values fall back to defaults",
+			labels: map[string]string{httpPathLabel: "/", httpSchemeLabel: "https", httpStatusLabel: "300-200"},
+			want: &HTTPCheck{
+				Scheme: "https", Method: http.MethodGet, Path: "/", Port: 443, StatusMin: 200, StatusMax: 399,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, newHTTPCheck(tt.labels, tt.ports))
+		})
+	}
+}
+
+func TestHTTPProber_Probe(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.Write([]byte(`{"status":"ok"}`))
+		case "/host":
+			if r.Host != "example.com" || (r.TLS != nil && r.TLS.ServerName != "example.com") {
+				w.WriteHeader(http.StatusMisdirectedRequest)
+			}
+		case "/redirect":
+			http.Redirect(w, r, "/healthz", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+
+	tests := []struct {
+		name       string
+		server     *httptest.Server
+		check      HTTPCheck
+		want       bool
+		statusCode int
+		certExpiry bool
+	}{
+		{
+			name:       "Healthy",
+			server:     server,
+			check:      HTTPCheck{Path: "/healthz", Body: "ok", Regex: regexp.MustCompile(`"status":\s*"ok"`)},
+			want:       true,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Regex mismatch",
+			server:     server,
+			check:      HTTPCheck{Path: "/healthz", Regex: regexp.MustCompile(`"status":\s*"serving"`)},
+			want:       false,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:   "Invalid config is not requested",
+			server: server,
+			check:  HTTPCheck{Path: "/healthz", Invalid: errors.New("invalid pinger.http.regex label")},
+			want:   false,
+		},
+		{
+			name:       "Unexpected status",
+			server:     server,
+			check:      HTTPCheck{Path: "/down"},
+			want:       false,
+			statusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "Redirect is not followed",
+			server:     server,
+			check:      HTTPCheck{Path: "/redirect", StatusMax: 299},
+			want:       false,
+			statusCode: http.StatusFound,
+		},
+		{
+			name:       "Body mismatch",
+			server:     server,
+			check:      HTTPCheck{Path: "/healthz", Body: "serving"},
+			want:       false,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "TLS without verification",
+			server:     tlsServer,
+			check:      HTTPCheck{Scheme: "https", Path: "/healthz", SkipVerify: true},
+			want:       true,
+			statusCode: http.StatusOK,
+			certExpiry: true,
+		},
+		{
+			name:       "Host and SNI from label",
+			server:     tlsServer,
+			check:      HTTPCheck{Scheme: "https", Host: "example.com", Path: "/host", SkipVerify: true},
+			want:       true,
+			statusCode: http.StatusOK,
+			certExpiry: true,
+		},
+		{
+			name:       "Untrusted certificate keeps expiry",
+			server:     tlsServer,
+			check:      HTTPCheck{Scheme: "https", Path: "/healthz"},
+			want:       false,
+			certExpiry: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.server.URL)
+			require.NoError(t, err)
+			host, portStr, err := net.SplitHostPort(u.Host)
+			require.NoError(t, err)
+			port, err := strconv.Atoi(portStr)
+			require.NoError(t, err)
+
+			check := tt.check
+			check.Port = port
+			if check.Scheme == "" {
+				check.Scheme = "http"
+			}
+			if check.Method == "" {
+				check.Method = http.MethodGet
+			}
+			if check.StatusMin == 0 {
+				check.StatusMin = 200
+			}
+			if check.StatusMax == 0 {
+				check.StatusMax = 399
+			}
+
+			results := NewHTTPProber(time.Second).Probe(context.Background(), Target{IP: host, HTTP: &check})
+			require.Len(t, results, 1)
+
+			result := results[0]
+			require.Equal(t, tt.want, result.Success, result.Error)
+			require.Equal(t, tt.statusCode, result.StatusCode)
+			require.Equal(t, tt.certExpiry, result.CertExpiry != "")
+		})
+	}
+}
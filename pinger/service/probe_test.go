@@ -0,0 +1,127 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProbeTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	open := listener.Addr().(*net.TCPAddr).Port
+
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed := closedListener.Addr().(*net.TCPAddr).Port
+	closedListener.Close()
+
+	tests := []struct {
+		name string
+		port int
+		want bool
+	}{
+		{
+			name: "Port accepts connections",
+			port: open,
+			want: true,
+		},
+		{
+			name: "Port closed",
+			port: closed,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := probeTCP(context.Background(), Target{IP: "127.0.0.1"}, tt.port, time.Second)
+
+			require.Equal(t, tt.want, result.Success)
+			require.Equal(t, tt.port, result.Port)
+			require.Equal(t, tt.want, result.Error == "")
+		})
+	}
+}
+
+func TestProbers_Dial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// адрес цели недоступен из сети теста, подключения перенаправляются через Target.Dial
+	var dialed []string
+	target := Target{
+		IP:   "192.0.2.1",
+		HTTP: &HTTPCheck{Scheme: "http", Method: http.MethodGet, Path: "/", Port: 80, StatusMin: 200, StatusMax: 399},
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialed = append(dialed, address)
+			var d net.Dialer
+			return d.DialContext(ctx, network, server.Listener.Addr().String())
+		},
+	}
+
+	tcp := probeTCP(context.Background(), target, 80, time.Second)
+	require.True(t, tcp.Success, tcp.Error)
+
+	results := NewHTTPProber(time.Second).Probe(context.Background(), target)
+	require.Len(t, results, 1)
+	require.True(t, results[0].Success, results[0].Error)
+
+	require.Equal(t, []string{"192.0.2.1:80", "192.0.2.1:80"}, dialed)
+}
+
+func TestServiceReachable(t *testing.T) {
+	tests := []struct {
+		name      string
+		results   []contracts.ProbeResult
+		reachable bool
+		ok        bool
+	}{
+		{
+			name:      "No probes",
+			reachable: true,
+			ok:        false,
+		},
+		{
+			name: "Only DNS probes",
+			results: []contracts.ProbeResult{
+				{Type: contracts.ProbeDNS, Success: false},
+			},
+			reachable: true,
+			ok:        false,
+		},
+		{
+			name: "All service probes succeeded",
+			results: []contracts.ProbeResult{
+				{Type: contracts.ProbeTCP, Success: true},
+				{Type: contracts.ProbeHTTP, Success: true},
+				{Type: contracts.ProbeDNS, Success: false},
+			},
+			reachable: true,
+			ok:        true,
+		},
+		{
+			name: "Service probe failed",
+			results: []contracts.ProbeResult{
+				{Type: contracts.ProbeTCP, Success: true},
+				{Type: contracts.ProbeHTTP, Success: false},
+			},
+			reachable: false,
+			ok:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reachable, ok := serviceReachable(tt.results)
+
+			require.Equal(t, tt.reachable, reachable)
+			require.Equal(t, tt.ok, ok)
+		})
+	}
+}
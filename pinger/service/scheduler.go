@@ -0,0 +1,70 @@
+package service
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Scheduler планирует проверки целей: каждая цель проверяется со своим интервалом Interval,
+// время следующей проверки смещается на случайную долю jitter интервала, чтобы проверки
+// не выполнялись одновременно. Пока проверка цели не завершена (Done), цель не планируется повторно
+type Scheduler struct {
+	next    map[string]time.Time
+	running map[string]struct{}
+	rand    func() float64
+	mu      sync.Mutex
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		next:    map[string]time.Time{},
+		running: map[string]struct{}{},
+		rand:    rand.Float64,
+	}
+}
+
+// Due возвращает цели из targets, проверку которых пора выполнить на момент now, и планирует
+// их следующую проверку. Первая проверка новой цели смещается на случайное время в пределах
+// доли jitter интервала, цели, которых нет в targets, забываются
+func (s *Scheduler) Due(targets []Target, jitter float64, now time.Time) []Target {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := make(map[string]time.Time, len(targets))
+
+	var due []Target
+	for _, t := range targets {
+		at, ok := s.next[t.Key]
+		if !ok {
+			at = now.Add(time.Duration(s.rand() * jitter * float64(t.Interval)))
+		}
+
+		if _, running := s.running[t.Key]; !running && !at.After(now) {
+			due = append(due, t)
+			s.running[t.Key] = struct{}{}
+
+			offset := (2*s.rand() - 1) * jitter * float64(t.Interval)
+			at = now.Add(t.Interval + time.Duration(offset))
+		}
+
+		next[t.Key] = at
+	}
+	s.next = next
+
+	return due
+}
+
+// Done отмечает завершение проверки цели key
+func (s *Scheduler) Done(key string) {
+	s.mu.Lock()
+	delete(s.running, key)
+	s.mu.Unlock()
+}
+
+// Trigger планирует проверку цели key на ближайший вызов Due
+func (s *Scheduler) Trigger(key string) {
+	s.mu.Lock()
+	s.next[key] = time.Time{}
+	s.mu.Unlock()
+}
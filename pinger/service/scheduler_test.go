@@ -0,0 +1,47 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Due(t *testing.T) {
+	db := Target{Interval: 2 * time.Second, ContainerInfo: contracts.ContainerInfo{Key: "app.db.1@app_default"}}
+	worker := Target{Interval: 5 * time.Minute, ContainerInfo: contracts.ContainerInfo{Key: "app.worker.1@app_default"}}
+	targets := []Target{db, worker}
+
+	s := NewScheduler()
+	s.rand = func() float64 { return 0.5 }
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// первая проверка смещается на половину доли jitter интервала
+	require.Empty(t, s.Due(targets, 0.2, now))
+	require.Equal(t, []Target{db}, s.Due(targets, 0.2, now.Add(200*time.Millisecond)))
+
+	// пока проверка не завершена, цель не планируется повторно
+	require.Empty(t, s.Due(targets, 0.2, now.Add(3*time.Second)))
+	s.Done(db.Key)
+	require.Equal(t, []Target{db}, s.Due(targets, 0.2, now.Add(3*time.Second)))
+	s.Done(db.Key)
+
+	// следующая проверка через интервал со смещением (2*0.5-1)*jitter = 0
+	require.Empty(t, s.Due(targets, 0.2, now.Add(4900*time.Millisecond)))
+	require.Equal(t, []Target{db}, s.Due(targets, 0.2, now.Add(5*time.Second)))
+	s.Done(db.Key)
+
+	require.Equal(t, []Target{db, worker}, s.Due(targets, 0.2, now.Add(30*time.Second)))
+	s.Done(db.Key)
+	s.Done(worker.Key)
+
+	// Trigger проверяет цель сразу
+	s.Trigger(worker.Key)
+	require.Equal(t, []Target{worker}, s.Due(targets, 0.2, now.Add(31*time.Second)))
+	s.Done(worker.Key)
+
+	// пропавшие цели забываются: после возвращения цель снова считается новой
+	require.Empty(t, s.Due([]Target{worker}, 0.2, now.Add(32*time.Second)))
+	require.Empty(t, s.Due(targets, 0.2, now.Add(32*time.Second)))
+	require.Equal(t, []Target{db}, s.Due(targets, 0.2, now.Add(32200*time.Millisecond)))
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"context"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	composeProjectLabel = "com.docker.compose.project"
+	composeServiceLabel = "com.docker.compose.service"
+	composeNumberLabel  = "com.docker.compose.container-number"
+	// tcpPortsLabel список TCP-портов через запятую, заменяет порты из ExposedPorts,
+	// пустое значение отключает TCP-проверки
+	tcpPortsLabel = "pinger.tcp.ports"
+	// intervalLabel и timeoutLabel интервал проверок контейнера и таймаут пинга, например 2s или 5m
+	intervalLabel = "pinger.interval"
+	timeoutLabel  = "pinger.timeout"
+
+	// ipv6KeySuffix добавляется к ключу IPv6-цели, чтобы адреса одного контейнера в одной сети
+	// проверялись и хранились отдельно
+	ipv6KeySuffix = ":ipv6"
+
+	// maxHealthOutput ограничение на длину вывода HEALTHCHECK, передаваемого backend'у
+	maxHealthOutput = 1024
+)
+
+// DialFunc подключение к адресу address в сети цели
+type DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// Target цель пинга - адрес контейнера в одной из его сетей, в dual-stack сети у контейнера
+// две цели: IPv4 и IPv6. Family - семейство IP (contracts.FamilyIPv4, contracts.FamilyIPv6). Нулевые PacketsCount, PingTimeout
+// и Interval означают настройки по умолчанию. PID - процесс контейнера для входа в его сетевое
+// пространство имен, Dial задается способом доступа к сети, nil означает подключение из сети pinger'а
+type Target struct {
+	IP           string
+	Family       string
+	NetworkID    string
+	TCPPorts     []int
+	HTTP         *HTTPCheck
+	GRPC         *GRPCCheck
+	DNSNames     []string
+	Health       *contracts.Health
+	PacketsCount int
+	PingTimeout  time.Duration
+	Interval     time.Duration
+	PID          int
+	Dial         DialFunc
+	contracts.ContainerInfo
+}
+
+// dial подключается к адресу address в сети цели
+func (t Target) dial(ctx context.Context, network, address string) (net.Conn, error) {
+	if t.Dial != nil {
+		return t.Dial(ctx, network, address)
+	}
+
+	var d net.Dialer
+	return d.DialContext(ctx, network, address)
+}
+
+// newTargets возвращает цели пинга для всех адресов контейнера во всех его сетях
+func newTargets(container types.ContainerJSON) []Target {
+	labels := map[string]string{}
+	image := ""
+	if container.Config != nil {
+		labels = container.Config.Labels
+		image = container.Config.Image
+	}
+
+	name := strings.TrimPrefix(container.Name, "/")
+	ports := tcpPorts(container)
+	httpCheck := newHTTPCheck(labels, ports)
+	grpcCheck := newGRPCCheck(labels)
+	health := newHealth(container)
+	interval := parseDuration(labels[intervalLabel])
+	timeout := parseDuration(labels[timeoutLabel])
+	pid := 0
+	if container.ContainerJSONBase != nil && container.State != nil {
+		pid = container.State.Pid
+	}
+
+	var targets []Target
+	if container.NetworkSettings == nil {
+		return targets
+	}
+
+	for netName, netSettings := range container.NetworkSettings.Networks {
+		if netSettings == nil {
+			continue
+		}
+
+		addresses := map[string]string{
+			contracts.FamilyIPv4: netSettings.IPAddress,
+			contracts.FamilyIPv6: netSettings.GlobalIPv6Address,
+		}
+
+		for _, family := range []string{contracts.FamilyIPv4, contracts.FamilyIPv6} {
+			if addresses[family] == "" {
+				continue
+			}
+
+			key := targetKey(name, netName, labels)
+			if family == contracts.FamilyIPv6 {
+				key += ipv6KeySuffix
+			}
+
+			targets = append(targets, Target{
+				IP:          addresses[family],
+				Family:      family,
+				NetworkID:   netSettings.NetworkID,
+				TCPPorts:    ports,
+				HTTP:        httpCheck,
+				GRPC:        grpcCheck,
+				Health:      health,
+				DNSNames:    dnsNames(labels, netSettings.Aliases, netSettings.DNSNames),
+				Interval:    interval,
+				PingTimeout: timeout,
+				PID:         pid,
+				ContainerInfo: contracts.ContainerInfo{
+					Key:         key,
+					ContainerID: container.ID,
+					Name:        name,
+					Image:       image,
+					Project:     labels[composeProjectLabel],
+					Service:     labels[composeServiceLabel],
+					Network:     netName,
+					Labels:      labels,
+				},
+			})
+		}
+	}
+
+	return targets
+}
+
+// targetKey возвращает идентификатор цели, который сохраняется при пересоздании контейнера:
+// для контейнеров docker compose - project.service.номер@сеть, для остальных - имя@сеть.
+// К ключу IPv6-адреса добавляется ipv6KeySuffix
+func targetKey(name, network string, labels map[string]string) string {
+	project, service := labels[composeProjectLabel], labels[composeServiceLabel]
+	if project == "" || service == "" {
+		return fmt.Sprintf("%s@%s", name, network)
+	}
+
+	number := labels[composeNumberLabel]
+	if number == "" {
+		number = "1"
+	}
+
+	return fmt.Sprintf("%s.%s.%s@%s", project, service, number, network)
+}
+
+// tcpPorts возвращает отсортированный список TCP-портов контейнера: из метки tcpPortsLabel,
+// а при ее отсутствии - из ExposedPorts и опубликованных портов
+func tcpPorts(container types.ContainerJSON) []int {
+	if container.Config != nil {
+		if value, ok := container.Config.Labels[tcpPortsLabel]; ok {
+			return parsePorts(value)
+		}
+	}
+
+	set := map[int]struct{}{}
+	if container.Config != nil {
+		for port := range container.Config.ExposedPorts {
+			if port.Proto() == "tcp" && port.Int() > 0 {
+				set[port.Int()] = struct{}{}
+			}
+		}
+	}
+	if container.NetworkSettings != nil {
+		for port := range container.NetworkSettings.Ports {
+			if port.Proto() == "tcp" && port.Int() > 0 {
+				set[port.Int()] = struct{}{}
+			}
+		}
+	}
+
+	var ports []int
+	for port := range set {
+		ports = append(ports, port)
+	}
+	slices.Sort(ports)
+
+	return ports
+}
+
+// parseDuration разбирает длительность из значения метки, некорректное или неположительное значение дает 0
+func parseDuration(value string) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0
+	}
+
+	return d
+}
+
+// parsePorts разбирает список портов через запятую, некорректные значения пропускаются
+func parsePorts(value string) []int {
+	var ports []int
+	for _, s := range strings.Split(value, ",") {
+		port, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || port < 1 || port > 65535 {
+			continue
+		}
+		if !slices.Contains(ports, port) {
+			ports = append(ports, port)
+		}
+	}
+	slices.Sort(ports)
+
+	return ports
+}
+
+// newHealth возвращает состояние HEALTHCHECK контейнера или nil, если проверка не настроена
+func newHealth(container types.ContainerJSON) *contracts.Health {
+	if container.ContainerJSONBase == nil || container.State == nil || container.State.Health == nil {
+		return nil
+	}
+
+	state := container.State.Health
+	health := &contracts.Health{
+		Status:        state.Status,
+		FailingStreak: state.FailingStreak,
+	}
+
+	if len(state.Log) > 0 {
+		output := strings.TrimSpace(state.Log[len(state.Log)-1].Output)
+		if len(output) > maxHealthOutput {
+			output = strings.ToValidUTF8(output[:maxHealthOutput], "")
+		}
+		health.Output = output
+	}
+
+	return health
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"app-pinger/pkg/contracts"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewTargets(t *testing.T) {
+	composeLabels := map[string]string{
+		composeProjectLabel: "app",
+		composeServiceLabel: "backend",
+		composeNumberLabel:  "2",
+	}
+
+	tests := []struct {
+		name      string
+		container types.ContainerJSON
+		want      []Target
+	}{
+		{
+			name: "Compose container",
+			container: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{ID: "4f1c2d3e", Name: "/app-backend-2"},
+				Config:            &container.Config{Image: "app-backend:latest", Labels: composeLabels},
+				NetworkSettings: &types.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+					"app_default": {NetworkID: "net1", IPAddress: "172.10.0.2", Aliases: []string{"app-backend-2", "backend"}},
+				}},
+			},
+			want: []Target{{
+				IP:        "172.10.0.2",
+				Family:    contracts.FamilyIPv4,
+				NetworkID: "net1",
+				DNSNames:  []string{"backend", "app-backend-2"},
+				ContainerInfo: contracts.ContainerInfo{
+					Key:         "app.backend.2@app_default",
+					ContainerID: "4f1c2d3e",
+					Name:        "app-backend-2",
+					Image:       "app-backend:latest",
+					Project:     "app",
+					Service:     "backend",
+					Network:     "app_default",
+					Labels:      composeLabels,
+				},
+			}},
+		},
+		{
+			name: "Plain container without IP in network",
+			container: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{ID: "9a8b7c6d", Name: "/redis"},
+				Config:            &container.Config{Image: "redis:7"},
+				NetworkSettings: &types.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+					"bridge": {NetworkID: "net2", IPAddress: "172.17.0.3"},
+					"none":   {NetworkID: "net3"},
+				}},
+			},
+			want: []Target{{
+				IP:        "172.17.0.3",
+				Family:    contracts.FamilyIPv4,
+				NetworkID: "net2",
+				ContainerInfo: contracts.ContainerInfo{
+					Key:         "redis@bridge",
+					ContainerID: "9a8b7c6d",
+					Name:        "redis",
+					Image:       "redis:7",
+					Network:     "bridge",
+				},
+			}},
+		},
+		{
+			name: "Dual-stack network",
+			container: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{ID: "1a2b3c4d", Name: "/nginx"},
+				Config:            &container.Config{Image: "nginx:1.27"},
+				NetworkSettings: &types.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+					"dual": {NetworkID: "net4", IPAddress: "172.30.0.2", GlobalIPv6Address: "fd00:30::2"},
+				}},
+			},
+			want: []Target{
+				{
+					IP:        "172.30.0.2",
+					Family:    contracts.FamilyIPv4,
+					NetworkID: "net4",
+					ContainerInfo: contracts.ContainerInfo{
+						Key:         "nginx@dual",
+						ContainerID: "1a2b3c4d",
+						Name:        "nginx",
+						Image:       "nginx:1.27",
+						Network:     "dual",
+					},
+				},
+				{
+					IP:        "fd00:30::2",
+					Family:    contracts.FamilyIPv6,
+					NetworkID: "net4",
+					ContainerInfo: contracts.ContainerInfo{
+						Key:         "nginx@dual:ipv6",
+						ContainerID: "1a2b3c4d",
+						Name:        "nginx",
+						Image:       "nginx:1.27",
+						Network:     "dual",
+					},
+				},
+			},
+		},
+		{
+			name: "IPv6-only network",
+			container: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{ID: "5e6f7a8b", Name: "/dns"},
+				Config:            &container.Config{Image: "coredns:1.11"},
+				NetworkSettings: &types.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+					"v6only": {NetworkID: "net5", GlobalIPv6Address: "fd00:40::3"},
+				}},
+			},
+			want: []Target{{
+				IP:        "fd00:40::3",
+				Family:    contracts.FamilyIPv6,
+				NetworkID: "net5",
+				ContainerInfo: contracts.ContainerInfo{
+					Key:         "dns@v6only:ipv6",
+					ContainerID: "5e6f7a8b",
+					Name:        "dns",
+					Image:       "coredns:1.11",
+					Network:     "v6only",
+				},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, newTargets(tt.container))
+		})
+	}
+}
+
+func TestTCPPorts(t *testing.T) {
+	tests := []struct {
+		name      string
+		container types.ContainerJSON
+		want      []int
+	}{
+		{
+			name: "Exposed and published ports",
+			container: types.ContainerJSON{
+				Config: &container.Config{ExposedPorts: nat.PortSet{"8080/tcp": {}, "53/udp": {}}},
+				NetworkSettings: &types.NetworkSettings{NetworkSettingsBase: types.NetworkSettingsBase{
+					Ports: nat.PortMap{"443/tcp": nil, "8080/tcp": nil},
+				}},
+			},
+			want: []int{443, 8080},
+		},
+		{
+			name: "Ports from label",
+			container: types.ContainerJSON{
+				Config: &container.Config{
+					ExposedPorts: nat.PortSet{"8080/tcp": {}},
+					Labels:       map[string]string{tcpPortsLabel: "9000, 80,bad,80"},
+				},
+			},
+			want: []int{80, 9000},
+		},
+		{
+			name: "Probes disabled by label",
+			container: types.ContainerJSON{
+				Config: &container.Config{
+					ExposedPorts: nat.PortSet{"8080/tcp": {}},
+					Labels:       map[string]string{tcpPortsLabel: ""},
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tcpPorts(tt.container))
+		})
+	}
+}
+
+func TestNewHealth(t *testing.T) {
+	tests := []struct {
+		name      string
+		container types.ContainerJSON
+		want      *contracts.Health
+	}{
+		{
+			name: "No healthcheck",
+			container: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{Running: true}},
+			},
+			want: nil,
+		},
+		{
+			name: "Unhealthy",
+			container: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{
+					Health: &types.Health{
+						Status:        types.Unhealthy,
+						FailingStreak: 3,
+						Log: []*types.HealthcheckResult{
+							{ExitCode: 0, Output: "ok"},
+							{ExitCode: 1, Output: "curl: (7) Failed to connect\n"},
+						},
+					},
+				}},
+			},
+			want: &contracts.Health{Status: types.Unhealthy, FailingStreak: 3, Output: "curl: (7) Failed to connect"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, newHealth(tt.container))
+		})
+	}
+}
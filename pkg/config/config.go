@@ -15,10 +15,11 @@ type DataBase struct {
 }
 
 type RabbitMQ struct {
-	User     string `env:"RABBITMQ_USER"`
-	Password string `env:"RABBITMQ_PASS"`
-	Host     string `env:"RABBITMQ_HOST"`
-	Queue    string `env:"RABBITMQ_QUEUE"`
+	User        string `env:"RABBITMQ_USER"`
+	Password    string `env:"RABBITMQ_PASS"`
+	Host        string `env:"RABBITMQ_HOST"`
+	Queue       string `env:"RABBITMQ_QUEUE"`
+	EventsQueue string `env:"RABBITMQ_EVENTS_QUEUE" env-default:"container_events"`
 }
 
 func ConfigLoad(cfg interface{}) {
@@ -1,11 +1,98 @@
 package contracts
 
-import "unicode/utf8"
+import (
+	"net/netip"
+	"unicode/utf8"
+)
 
+// Семейства IP-адресов цели
+const (
+	FamilyIPv4 = "ipv4"
+	FamilyIPv6 = "ipv6"
+)
+
+// IPFamily возвращает семейство адреса IP или пустую строку, если адрес некорректен
+func IPFamily(IP string) string {
+	addr, err := netip.ParseAddr(IP)
+	if err != nil {
+		return ""
+	}
+
+	if addr.Unmap().Is4() {
+		return FamilyIPv4
+	}
+
+	return FamilyIPv6
+}
+
+// PingStats статистика пинга, время задержек указано в миллисекундах
+type PingStats struct {
+	PacketsSent int     `json:"packets_sent"`
+	PacketsRecv int     `json:"packets_recv"`
+	PacketLoss  float64 `json:"packet_loss"`
+	MinRtt      float64 `json:"min_rtt"`
+	AvgRtt      float64 `json:"avg_rtt"`
+	MaxRtt      float64 `json:"max_rtt"`
+	StdDevRtt   float64 `json:"stddev_rtt"`
+	Jitter      float64 `json:"jitter"`
+}
+
+// ContainerInfo данные контейнера и сети, в которой он пингуется. Key - стабильный идентификатор,
+// который не меняется при пересоздании контейнера
+type ContainerInfo struct {
+	Key         string            `json:"key"`
+	ContainerID string            `json:"container_id"`
+	Name        string            `json:"container_name"`
+	Image       string            `json:"image"`
+	Project     string            `json:"compose_project"`
+	Service     string            `json:"compose_service"`
+	Network     string            `json:"network"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+const (
+	ProbeTCP  = "tcp"
+	ProbeHTTP = "http"
+	ProbeDNS  = "dns"
+	ProbeGRPC = "grpc"
+)
+
+// ProbeResult результат проверки сервиса контейнера, Latency указана в миллисекундах.
+// Target - адрес проверки (URL, DNS-имя), CertExpiry - окончание действия TLS-сертификата,
+// Addresses - ответ DNS-сервера, Status - состояние сервиса по протоколу grpc.health.v1
+type ProbeResult struct {
+	Type       string   `json:"type"`
+	Target     string   `json:"target,omitempty"`
+	Port       int      `json:"port,omitempty"`
+	Success    bool     `json:"success"`
+	Latency    float64  `json:"latency"`
+	StatusCode int      `json:"status_code,omitempty"`
+	CertExpiry string   `json:"cert_expiry,omitempty"`
+	Addresses  []string `json:"addresses,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// Health состояние HEALTHCHECK контейнера по данным Docker, Output - вывод последней проверки
+type Health struct {
+	Status        string `json:"status"`
+	FailingStreak int    `json:"failing_streak"`
+	Output        string `json:"output,omitempty"`
+}
+
+// PingData результат проверки цели. CheckError - причина, по которой pinger не смог выполнить
+// ICMP-пинг (нет прав на сокет, нет доступа к сети цели): недоступность в этом случае означает,
+// что состояние контейнера неизвестно, а не что он не отвечает. Family - семейство IPAddress
 type PingData struct {
 	IPAddress   string `json:"ip_address"`
+	Family      string `json:"ip_family"`
 	IsReachable bool   `json:"is_reachable"`
 	LastPing    string `json:"last_ping"`
+	ContainerInfo
+	PingStats
+	Probes     []ProbeResult `json:"probes,omitempty"`
+	Health     *Health       `json:"health,omitempty"`
+	CheckError string        `json:"check_error,omitempty"`
 }
 
 type ContainerAddReq struct {
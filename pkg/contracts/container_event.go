@@ -0,0 +1,39 @@
+package contracts
+
+import "unicode/utf8"
+
+// Действия жизненного цикла контейнера, о которых pinger сообщает backend'у. ActionRemoved -
+// отметка о том, что цель больше не проверяется: контейнер удален, отключен от сети или исключен фильтром
+const (
+	ActionStart   = "start"
+	ActionStop    = "stop"
+	ActionDie     = "die"
+	ActionOOM     = "oom"
+	ActionRemoved = "removed"
+)
+
+// ContainerEvent событие жизненного цикла контейнера в сети цели. ExitCode и OOMKilled
+// заполняются для остановленного контейнера по данным inspect
+type ContainerEvent struct {
+	Action    string `json:"action"`
+	Time      string `json:"time"`
+	ExitCode  int    `json:"exit_code"`
+	OOMKilled bool   `json:"oom_killed"`
+	ContainerInfo
+}
+
+// ContainerEventReq сообщение с событиями контейнеров, отправляется в отдельную очередь RabbitMQ
+type ContainerEventReq struct {
+	Events []ContainerEvent `json:"events"`
+}
+
+func (req *ContainerEventReq) IsValid() bool {
+	for _, e := range req.Events {
+		if utf8.RuneCountInString(e.Key) > 0 && utf8.RuneCountInString(e.Action) > 0 &&
+			utf8.RuneCountInString(e.Time) > 0 {
+			return true
+		}
+	}
+
+	return false
+}